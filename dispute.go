@@ -0,0 +1,140 @@
+package dkg
+
+import "github.com/dedis/kyber"
+
+// Complaint is raised by a node against a dealer whose shares failed
+// ProcessSecretShareVerification. It names the disputed parties so the
+// accused can answer with a Justification; it does not need to carry the
+// disputed shares themselves, since ProcessComplaint re-evaluates them
+// directly from the accused's own polynomials rather than trusting the
+// accuser's copy.
+//
+// A Complaint carries neither the disputed EncryptedShare (see elgamal.go)
+// nor a proof of its decryption; the dispute round settles on the shares
+// the accused reveals in its Justification.
+type Complaint struct {
+	Accuser kyber.Scalar
+	Accused kyber.Scalar
+}
+
+// Justification is the accused dealer's response to a Complaint: the shares
+// it actually dealt to the accuser, published in the clear so any observer
+// can settle the dispute without trusting either party. Accuser records
+// which id the shares were evaluated at, so ProcessJustification verifies
+// them at the accuser's id rather than the observer's own.
+type Justification struct {
+	Accuser        kyber.Scalar
+	Accused        kyber.Scalar
+	RevealedShare1 kyber.Scalar
+	RevealedShare2 kyber.Scalar
+}
+
+// RaiseComplaint is called by a node when ProcessSecretShareVerification
+// reports that the shares dealt by the participant `against` do not match
+// its published verification points.
+func (n *node) RaiseComplaint(against kyber.Scalar) (*Complaint, error) {
+	if _, err := n.findParticipant(against); err != nil {
+		return nil, err
+	}
+
+	verified, err := n.ProcessSecretShareVerification(against)
+	if err != nil {
+		return nil, err
+	}
+	if verified {
+		return nil, SharesVerifiedError{Accused: against}
+	}
+
+	return &Complaint{
+		Accuser: n.id,
+		Accused: against,
+	}, nil
+}
+
+// ProcessComplaint lets the accused dealer answer a Complaint by publishing,
+// in the clear, the shares it actually dealt to the accuser.
+func (n *node) ProcessComplaint(c *Complaint) (*Justification, error) {
+	if !n.id.Equal(c.Accused) {
+		return nil, MisdirectedComplaintError{Accused: c.Accused, Self: n.id}
+	}
+
+	share1, share2 := n.EvaluatePolynomials(c.Accuser)
+	return &Justification{
+		Accuser:        c.Accuser,
+		Accused:        n.id,
+		RevealedShare1: share1,
+		RevealedShare2: share2,
+	}, nil
+}
+
+// ProcessJustification lets any observer decide, independent of the
+// accuser's say-so, whether the accused dealer should be disqualified. It
+// recomputes the accused's published verification points against the
+// revealed shares, evaluated at j.Accuser rather than this node's own id, so
+// the check is correct regardless of who happens to be the observer; if they
+// are inconsistent the complaint was genuine and the accused is added to
+// this node's Disqualified set.
+func (n *node) ProcessJustification(j *Justification) (bool, error) {
+	accused, err := n.findParticipant(j.Accused)
+	if err != nil {
+		return false, err
+	}
+
+	shares := []kyber.Scalar{j.RevealedShare1}
+	if n.vss.NumPolynomials() > 1 {
+		shares = append(shares, j.RevealedShare2)
+	}
+
+	if n.vss.Verify(j.Accuser, shares, accused.verificationPoints) {
+		return false, nil
+	}
+
+	n.disqualify(j.Accused)
+	return true, nil
+}
+
+func (n *node) disqualify(id kyber.Scalar) {
+	if n.isDisqualified(id) {
+		return
+	}
+	n.Disqualified = append(n.Disqualified, id)
+}
+
+func (n *node) isDisqualified(id kyber.Scalar) bool {
+	for _, d := range n.Disqualified {
+		if d.Equal(id) {
+			return true
+		}
+	}
+	return false
+}
+
+// QualifiedSet returns the ids of the other participants this node has not
+// disqualified.
+func (n *node) QualifiedSet() []kyber.Scalar {
+	qual := make([]kyber.Scalar, 0, len(n.otherParticipants))
+	for _, p := range n.otherParticipants {
+		if !n.isDisqualified(p.id) {
+			qual = append(qual, p.id)
+		}
+	}
+	return qual
+}
+
+// GroupPublicKey aggregates the PublicKeyPart of this node and of every
+// other participant still in the QUAL set, i.e. not present in
+// Disqualified. It folds in each participant's own broadcast PublicKeyPart
+// rather than its raw verificationPoints[0]: under PedersenVSS those are not
+// the same point, since the commitment is blinded by g2 while
+// PublicKeyPart strips it, and every observer must fold in the same,
+// unblinded value to agree on the group key.
+func (n *node) GroupPublicKey() kyber.Point {
+	y := n.PublicKeyPart()
+	for _, p := range n.otherParticipants {
+		if n.isDisqualified(p.id) || p.PublicKeyPart == nil {
+			continue
+		}
+		y = n.curve.Point().Add(y, p.PublicKeyPart)
+	}
+	return y
+}
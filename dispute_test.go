@@ -0,0 +1,214 @@
+package dkg
+
+import (
+	"testing"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/pairing/bn256"
+)
+
+// newQualTestNode builds a node around the standard testing polynomials with
+// id as its own identifier.
+func newQualTestNode(t *testing.T, curve kyber.Group, g2 kyber.Point, id kyber.Scalar, poly1, poly2 ScalarPolynomial) *node {
+	n, err := NewNode(curve, g2, curve.Scalar().Zero(), 0, id, poly1, poly2, NewPedersenVSS(curve, g2))
+	if err != nil {
+		t.Fatalf("could not construct node %v: %v", id, err)
+	}
+	return n
+}
+
+func TestComplaintAgainstBadDealer(t *testing.T) {
+	curve, g2, _, _, dealerID, dealerPoly1, dealerPoly2 := getValidNodeParamsForTesting(t)
+	accuserID := curve.Scalar().SetInt64(54321)
+
+	dealer := newQualTestNode(t, curve, g2, dealerID, dealerPoly1, dealerPoly2)
+	accuser := newQualTestNode(t, curve, g2, accuserID, dealerPoly1, dealerPoly2)
+
+	// The dealer deals a share that does not match its own commitments.
+	badShare1 := curve.Scalar().SetInt64(1)
+	badShare2 := curve.Scalar().SetInt64(1)
+	addParticipantToNodeList(accuser, dealerID, badShare1, badShare2, dealer.VerificationPoints(), dealer.PublicKeyPart())
+
+	verified, err := accuser.ProcessSecretShareVerification(dealerID)
+	if err != nil || verified {
+		t.Fatalf("expected the bad share to fail verification, got verified=%v err=%v", verified, err)
+	}
+
+	if _, err := accuser.RaiseComplaint(dealerID); err != nil {
+		t.Fatalf("RaiseComplaint failed: %v", err)
+	}
+
+	// A dealer that cannot produce shares consistent with its own published
+	// commitments stays unable to justify the complaint even once accused;
+	// simulate that by having it stand behind the same bad shares instead of
+	// revealing values that match dealer.VerificationPoints().
+	justification := &Justification{
+		Accuser:        accuserID,
+		Accused:        dealerID,
+		RevealedShare1: badShare1,
+		RevealedShare2: badShare2,
+	}
+
+	disqualified, err := accuser.ProcessJustification(justification)
+	if err != nil {
+		t.Fatalf("ProcessJustification failed: %v", err)
+	}
+	if !disqualified {
+		t.Errorf("expected the dealer to be disqualified after a genuine complaint")
+	}
+	if !accuser.isDisqualified(dealerID) {
+		t.Errorf("expected dealer %v to be in the Disqualified set", dealerID)
+	}
+}
+
+func TestMaliciousComplaintAgainstHonestDealer(t *testing.T) {
+	curve, g2, _, _, dealerID, dealerPoly1, dealerPoly2 := getValidNodeParamsForTesting(t)
+	accuserID := curve.Scalar().SetInt64(54321)
+
+	dealer := newQualTestNode(t, curve, g2, dealerID, dealerPoly1, dealerPoly2)
+	accuser := newQualTestNode(t, curve, g2, accuserID, dealerPoly1, dealerPoly2)
+
+	share1, share2 := dealer.EvaluatePolynomials(accuserID)
+	addParticipantToNodeList(accuser, dealerID, share1, share2, dealer.VerificationPoints(), dealer.PublicKeyPart())
+
+	verified, err := accuser.ProcessSecretShareVerification(dealerID)
+	if err != nil || !verified {
+		t.Fatalf("expected the honestly-dealt share to verify, got verified=%v err=%v", verified, err)
+	}
+
+	// A malicious accuser complains anyway, but the dealer answers by
+	// re-evaluating its own polynomials rather than trusting the complaint,
+	// so the fabricated share never enters the picture.
+	complaint := &Complaint{
+		Accuser: accuserID,
+		Accused: dealerID,
+	}
+
+	justification, err := dealer.ProcessComplaint(complaint)
+	if err != nil {
+		t.Fatalf("ProcessComplaint failed: %v", err)
+	}
+
+	disqualified, err := accuser.ProcessJustification(justification)
+	if err != nil {
+		t.Fatalf("ProcessJustification failed: %v", err)
+	}
+	if disqualified {
+		t.Errorf("expected the honest dealer's justification to clear it, not disqualify it")
+	}
+	if accuser.isDisqualified(dealerID) {
+		t.Errorf("honest dealer %v should not be in the Disqualified set", dealerID)
+	}
+}
+
+// TestProcessJustificationByThirdPartyObserver checks that an observer which
+// is neither the accuser nor the accused can still correctly settle a
+// dispute: ProcessJustification must verify the revealed shares at the
+// accuser's id, not the observer's own, or it would wrongly disqualify an
+// honest dealer whenever judged by anyone but the original accuser.
+func TestProcessJustificationByThirdPartyObserver(t *testing.T) {
+	curve, g2, _, _, dealerID, dealerPoly1, dealerPoly2 := getValidNodeParamsForTesting(t)
+	accuserID := curve.Scalar().SetInt64(54321)
+	observerID := curve.Scalar().SetInt64(98765)
+
+	dealer := newQualTestNode(t, curve, g2, dealerID, dealerPoly1, dealerPoly2)
+	observer := newQualTestNode(t, curve, g2, observerID, dealerPoly1, dealerPoly2)
+
+	share1, share2 := dealer.EvaluatePolynomials(accuserID)
+	addParticipantToNodeList(observer, dealerID, share1, share2, dealer.VerificationPoints(), dealer.PublicKeyPart())
+
+	complaint := &Complaint{Accuser: accuserID, Accused: dealerID}
+	justification, err := dealer.ProcessComplaint(complaint)
+	if err != nil {
+		t.Fatalf("ProcessComplaint failed: %v", err)
+	}
+
+	disqualified, err := observer.ProcessJustification(justification)
+	if err != nil {
+		t.Fatalf("ProcessJustification failed: %v", err)
+	}
+	if disqualified {
+		t.Errorf("expected the honest dealer's justification to clear it for a third-party observer, not disqualify it")
+	}
+	if observer.isDisqualified(dealerID) {
+		t.Errorf("honest dealer %v should not be in the observer's Disqualified set", dealerID)
+	}
+}
+
+func TestThresholdBoundaryDisqualification(t *testing.T) {
+	curve, g2, zkParam, timeout, id, poly1, poly2 := getValidNodeParamsForTesting(t)
+	threshold := len(poly1) // 4
+
+	observer := newQualTestNode(t, curve, g2, id, poly1, poly2)
+
+	var dealerIDs []kyber.Scalar
+	for i := int64(0); i < int64(threshold)+1; i++ {
+		dealerID := curve.Scalar().SetInt64(1000 + i)
+		dealer := newQualTestNode(t, curve, g2, dealerID, poly1, poly2)
+		share1, share2 := dealer.EvaluatePolynomials(id)
+		addParticipantToNodeList(observer, dealerID, share1, share2, dealer.VerificationPoints(), dealer.PublicKeyPart())
+		dealerIDs = append(dealerIDs, dealerID)
+	}
+
+	// Disqualify every dealer but one; the group key must still be
+	// computable from the single remaining qualified dealer plus the
+	// observer's own contribution.
+	for _, dealerID := range dealerIDs[:threshold] {
+		observer.disqualify(dealerID)
+	}
+
+	qual := observer.QualifiedSet()
+	if len(qual) != 1 {
+		t.Fatalf("expected exactly one qualified dealer left, got %d", len(qual))
+	}
+	if !qual[0].Equal(dealerIDs[threshold]) {
+		t.Errorf("expected %v to remain qualified, got %v", dealerIDs[threshold], qual[0])
+	}
+
+	if observer.GroupPublicKey() == nil {
+		t.Errorf("expected GroupPublicKey to still aggregate over the remaining quorum")
+	}
+	_ = zkParam
+	_ = timeout
+}
+
+// TestGroupPublicKeyAgreesAcrossObservers deals shares between two
+// PedersenVSS nodes built from independently-sampled secret polynomials, so
+// that (unlike every other test in this file, which reuses the same
+// poly1/poly2 for every simulated party) a's and b's commitments actually
+// differ. Both observers must still converge on the same group public key,
+// namely g^{a0+b0}, rather than on the Pedersen commitments' blinded C_0.
+func TestGroupPublicKeyAgreesAcrossObservers(t *testing.T) {
+	curve, g2, zkParam, timeout, _, _, _ := getValidNodeParamsForTesting(t)
+	threshold := 2
+
+	a, err := GenerateNode(curve, g2, zkParam, timeout, curve.Scalar().SetInt64(1), bn256.NewSuite().RandomStream(), threshold, NewPedersenVSS(curve, g2))
+	if err != nil {
+		t.Fatalf("could not construct node a: %v", err)
+	}
+	b, err := GenerateNode(curve, g2, zkParam, timeout, curve.Scalar().SetInt64(2), bn256.NewSuite().RandomStream(), threshold, NewPedersenVSS(curve, g2))
+	if err != nil {
+		t.Fatalf("could not construct node b: %v", err)
+	}
+
+	aShare1, aShare2 := a.EvaluatePolynomials(b.id)
+	addParticipantToNodeList(b, a.id, aShare1, aShare2, a.VerificationPoints(), a.PublicKeyPart())
+
+	bShare1, bShare2 := b.EvaluatePolynomials(a.id)
+	addParticipantToNodeList(a, b.id, bShare1, bShare2, b.VerificationPoints(), b.PublicKeyPart())
+
+	expected := curve.Point().Add(
+		curve.Point().Mul(a.secretPoly1[0], nil),
+		curve.Point().Mul(b.secretPoly1[0], nil),
+	)
+
+	if !a.GroupPublicKey().Equal(expected) {
+		t.Errorf("a's group public key does not match g^{a0+b0}")
+	}
+	if !b.GroupPublicKey().Equal(expected) {
+		t.Errorf("b's group public key does not match g^{a0+b0}")
+	}
+	if !a.GroupPublicKey().Equal(b.GroupPublicKey()) {
+		t.Errorf("a and b disagree on the group public key: %v vs %v", a.GroupPublicKey(), b.GroupPublicKey())
+	}
+}
@@ -9,7 +9,6 @@ import (
 
 	"github.com/dedis/kyber"
 	"github.com/dedis/kyber/pairing/bn256"
-	"github.com/dedis/kyber/util/random"
 	// "golang.org/x/crypto/openpgp/elgamal"
 )
 
@@ -50,52 +49,35 @@ func serializePoint(curve kyber.Group, pt kyber.Point) string {
 	return pt.String()
 }
 
+// addParticipantToNodeList records what n has learned about the participant
+// identified by id, replacing any record already on file for that id rather
+// than appending a second, stale entry that findParticipant's first-match
+// lookup would keep returning instead.
 func addParticipantToNodeList(
 	n *node,
 	id kyber.Scalar,
 	secretShare1 kyber.Scalar,
 	secretShare2 kyber.Scalar,
 	verificationPoints PointTuple,
+	publicKeyPart kyber.Point,
 ) *node {
 	participant := Participant{
-		id,
-		secretShare1,
-		secretShare2,
-		verificationPoints,
+		id:                 id,
+		secretShare1:       secretShare1,
+		secretShare2:       secretShare2,
+		verificationPoints: verificationPoints,
+		PublicKeyPart:      publicKeyPart,
+	}
+	for i := range n.otherParticipants {
+		if n.otherParticipants[i].id.Equal(id) {
+			n.otherParticipants[i] = participant
+			return n
+		}
 	}
 	n.otherParticipants = append(n.otherParticipants, participant)
 	return n
 }
 
-// ElGamal Encryption and Decryption from https://github.com/dedis/kyber/blob/master/examples/enc_test.go
-func ElGamalEncrypt(group kyber.Group, pubkey kyber.Point, message []byte) (
-	K, C kyber.Point, remainder []byte) {
-
-	// Embed the message (or as much of it as will fit) into a curve point.
-	M := group.Point().Embed(message, random.New())
-	max := group.Point().EmbedLen()
-	if max > len(message) {
-		max = len(message)
-	}
-	remainder = message[max:]
-	// ElGamal-encrypt the point to produce ciphertext (K,C).
-	k := group.Scalar().Pick(random.New()) // ephemeral private key
-	K = group.Point().Mul(k, nil)          // ephemeral DH public key
-	S := group.Point().Mul(k, pubkey)      // ephemeral DH shared secret
-	C = S.Add(S, M)                        // message blinded with secret
-	return
-}
-
-func ElGamalDecrypt(group kyber.Group, prikey kyber.Scalar, K, C kyber.Point) (
-	message []byte, err error) {
-
-	// ElGamal-decrypt the ciphertext (K,C) to reproduce the message.
-	S := group.Point().Mul(prikey, K) // regenerate shared secret
-	M := group.Point().Sub(C, S)      // use to un-blind the message
-	message, err = M.Data()           // extract the embedded data
-	return
-}
-
 func TestInvalidNodeConstruction(t *testing.T) {
 	curve, g2, zkParam, timeout, id, secretPoly1, secretPoly2 := getValidNodeParamsForTesting(t)
 
@@ -109,6 +91,7 @@ func TestInvalidNodeConstruction(t *testing.T) {
 			node, err := NewNode(
 				curve, bad, zkParam, timeout,
 				id, secretPoly1, secretPoly2,
+				NewPedersenVSS(curve, g2),
 			)
 			if node != nil && err == nil {
 				t.Errorf(
@@ -166,6 +149,7 @@ func TestInvalidNodeConstruction(t *testing.T) {
 			node, err := NewNode(
 				curve, g2, zkParam, timeout,
 				id, bad.poly1, bad.poly2,
+				NewPedersenVSS(curve, g2),
 			)
 			if node != nil && err == nil {
 				t.Errorf(
@@ -199,6 +183,7 @@ func TestValidNode(t *testing.T) {
 	node, err := NewNode(
 		curve, g2, zkParam, timeout,
 		id, secretPoly1, secretPoly2,
+		NewPedersenVSS(curve, g2),
 	)
 
 	if node == nil || err != nil {
@@ -242,6 +227,7 @@ func TestProcessSecretShareVerification(t *testing.T) {
 	node1, err := NewNode(
 		curve, g2, zkParam, timeout,
 		id, secretPoly1, secretPoly2,
+		NewPedersenVSS(curve, g2),
 	)
 
 	if node1 == nil || err != nil {
@@ -280,7 +266,7 @@ func TestProcessSecretShareVerification(t *testing.T) {
 			invalidShare1, invalidShare2 := curve.Scalar().SetInt64(9), curve.Scalar().SetInt64(9)
 			invalidPoints := PointTuple{curve.Point().Base()}
 			node2 := addParticipantToNodeList(
-				node1, validNodeID, invalidShare1, invalidShare2, invalidPoints,
+				node1, validNodeID, invalidShare1, invalidShare2, invalidPoints, curve.Point().Base(),
 			)
 
 			verified, err := node2.ProcessSecretShareVerification(id)
@@ -304,7 +290,7 @@ func TestProcessSecretShareVerification(t *testing.T) {
 			validShare1, validShare2 := node1.EvaluatePolynomials(validNodeID)
 			validPoints := node1.VerificationPoints()
 			node3 := addParticipantToNodeList(
-				node1, validNodeID, validShare1, validShare2, validPoints,
+				node1, validNodeID, validShare1, validShare2, validPoints, node1.PublicKeyPart(),
 			)
 
 			verified, err := node3.ProcessSecretShareVerification(validNodeID)
@@ -329,6 +315,7 @@ func TestEvaluatePolynomials(t *testing.T) {
 	node, err := NewNode(
 		curve, g2, zkParam, timeout,
 		id, secretPoly1, secretPoly2,
+		NewPedersenVSS(curve, g2),
 	)
 
 	// invalidID := curve.Scalar().SetInt64(9)
@@ -386,7 +373,7 @@ func TestGenerateNodeAndSecrets(t *testing.T) {
 
 	gNode, err := GenerateNode(
 		curve, g2, zkParam,
-		timeout, id, bn256.NewSuite().RandomStream(), threshold,
+		timeout, id, bn256.NewSuite().RandomStream(), threshold, NewPedersenVSS(curve, g2),
 	)
 	if gNode == nil || err != nil {
 		t.Errorf(
@@ -407,7 +394,7 @@ func TestGenerateNodeAndSecrets(t *testing.T) {
 		validShare1, validShare2 := gNode.EvaluatePolynomials(validNodeID)
 		validPoints := gNode.VerificationPoints()
 		gNode := addParticipantToNodeList(
-			gNode, validNodeID, validShare1, validShare2, validPoints,
+			gNode, validNodeID, validShare1, validShare2, validPoints, gNode.PublicKeyPart(),
 		)
 
 		verified, err := gNode.ProcessSecretShareVerification(validNodeID)
@@ -425,46 +412,3 @@ func TestGenerateNodeAndSecrets(t *testing.T) {
 	})
 
 }
-
-func TestShadowComputationAndExchange(t *testing.T) {
-	curve, g2, zkParam, timeout, id, _, _ := getValidNodeParamsForTesting(t)
-
-	// pub/priv keypair derived from trusted information center
-	k := curve.Scalar().Pick(curve.RandomStream()) // random value k (need new random stream)
-	y := curve.Point().Mul(k, nil)                 // pub key
-
-	// ElGamal encrypt a message
-	mBefore := []byte("gnosis prediction markets")
-	tHat, mEnc, _ := ElGamalEncrypt(curve, y, mBefore)
-
-	// DKG setup
-	threshold := 4
-	gNode, err := GenerateNode(
-		curve, g2, zkParam,
-		timeout, id, bn256.NewSuite().RandomStream(), threshold,
-	)
-	playeriID := curve.Scalar().SetInt64(12345)
-	si1, si2 := gNode.EvaluatePolynomials(playeriID)
-	playeri := gNode.VerificationPoints()
-	gNode = addParticipantToNodeList(
-		gNode, playeriID, si1, si2, playeri,
-	)
-	players := []curve.Scalar().Zero()
-	for i, participant := range gNode.otherParticipants {
-		players.append(participant.verificationPoints[0]) // ignoring second tuple value for now
-	}
-
-	key := // get access key n^-1 for this group?
-
-		t.Run("should decrypt message for player with shares", func(t *testing) {
-			mDec := ShadowDecrypt(curve, playeri[0], players, si1, key, tHat, mEnc)
-			if !mEnc.Equal(mDec) {
-				t.Errorf(
-					"decryption failed\n"+
-						"expected message: %v\n"+
-						"but received: %v\n",
-					mEnc, mDec,
-				)
-			}
-		})
-}
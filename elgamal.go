@@ -0,0 +1,147 @@
+package dkg
+
+import (
+	"fmt"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/util/random"
+)
+
+// ElGamalEncrypt ElGamal-encrypts message under pubkey, returning the
+// ephemeral public key K and the masked ciphertext point C. message must fit
+// within one Point's EmbedLen; any bytes beyond that are returned as
+// remainder and are not encrypted, mirroring the embedding limit of
+// Point.Embed.
+func ElGamalEncrypt(group kyber.Group, pubkey kyber.Point, message []byte) (
+	K, C kyber.Point, remainder []byte) {
+
+	// Embed the message (or as much of it as will fit) into a curve point.
+	M := group.Point().Embed(message, random.New())
+	max := group.Point().EmbedLen()
+	if max > len(message) {
+		max = len(message)
+	}
+	remainder = message[max:]
+	// ElGamal-encrypt the point to produce ciphertext (K,C).
+	k := group.Scalar().Pick(random.New()) // ephemeral private key
+	K = group.Point().Mul(k, nil)          // ephemeral DH public key
+	S := group.Point().Mul(k, pubkey)      // ephemeral DH shared secret
+	C = S.Add(S, M)                        // message blinded with secret
+	return
+}
+
+// ElGamalDecrypt reverses ElGamalEncrypt, recovering the embedded message
+// from the ciphertext (K,C) using the matching private key.
+func ElGamalDecrypt(group kyber.Group, prikey kyber.Scalar, K, C kyber.Point) (
+	message []byte, err error) {
+
+	// ElGamal-decrypt the ciphertext (K,C) to reproduce the message.
+	S := group.Point().Mul(prikey, K) // regenerate shared secret
+	M := group.Point().Sub(C, S)      // use to un-blind the message
+	message, err = M.Data()           // extract the embedded data
+	return
+}
+
+// EncryptedShare is what crosses an untrusted transport in place of the
+// clear-text (s1, s2) pair EvaluatePolynomials would otherwise hand to a
+// recipient: share1 and share2 embedded in C1 and C2 respectively, each
+// ElGamal-encrypted under the recipient's public key with its own
+// independently sampled ephemeral key (K1, K2). Using a separate mask per
+// chunk, rather than reusing one across both, keeps C1-S and C2-S from ever
+// cancelling to the same shared point, which would let an observer compute
+// share1-share2 without the recipient's private key at all.
+type EncryptedShare struct {
+	From, To kyber.Scalar
+	K1, K2   kyber.Point
+	C1, C2   kyber.Point
+}
+
+// EncryptSharesForParticipant deals this node's shares to a participant this
+// node has already recorded (see addParticipantToNodeList), encrypting them
+// under the ElGamal key it published as PubKey rather than requiring the
+// caller to look that key up and pass it to EncryptSharesFor directly.
+func (n *node) EncryptSharesForParticipant(recipientID kyber.Scalar) (*EncryptedShare, error) {
+	recipient, err := n.findParticipant(recipientID)
+	if err != nil {
+		return nil, err
+	}
+	return n.EncryptSharesFor(recipient.PubKey, recipientID)
+}
+
+// EncryptSharesFor evaluates this node's secret polynomials at recipientID,
+// exactly as EvaluatePolynomials does, then seals the resulting shares under
+// recipientPub so they can be dealt over an untrusted transport instead of a
+// private channel. It returns ShareTooLargeError if a marshaled share does
+// not fit within a single curve point's embedding capacity, rather than
+// silently shipping the overflow unencrypted.
+func (n *node) EncryptSharesFor(recipientPub kyber.Point, recipientID kyber.Scalar) (*EncryptedShare, error) {
+	share1, share2 := n.EvaluatePolynomials(recipientID)
+
+	b1, err := share1.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("dkg: could not marshal share1: %w", err)
+	}
+	b2, err := share2.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("dkg: could not marshal share2: %w", err)
+	}
+
+	max := n.curve.Point().EmbedLen()
+	if len(b1) > max {
+		return nil, ShareTooLargeError{Len: len(b1), Max: max}
+	}
+	if len(b2) > max {
+		return nil, ShareTooLargeError{Len: len(b2), Max: max}
+	}
+
+	K1, C1 := n.sealChunk(b1, recipientPub)
+	K2, C2 := n.sealChunk(b2, recipientPub)
+
+	return &EncryptedShare{
+		From: n.id,
+		To:   recipientID,
+		K1:   K1,
+		K2:   K2,
+		C1:   C1,
+		C2:   C2,
+	}, nil
+}
+
+// sealChunk ElGamal-encrypts chunk, which must already fit within one
+// Point's EmbedLen, under recipientPub using a freshly sampled ephemeral
+// key, returning that key's public half alongside the masked ciphertext
+// point.
+func (n *node) sealChunk(chunk []byte, recipientPub kyber.Point) (K, C kyber.Point) {
+	k := n.curve.Scalar().Pick(random.New())  // ephemeral private key
+	K = n.curve.Point().Mul(k, nil)           // ephemeral DH public key
+	S := n.curve.Point().Mul(k, recipientPub) // ephemeral DH shared secret
+
+	M := n.curve.Point().Embed(chunk, random.New())
+	C = n.curve.Point().Add(S, M)
+	return K, C
+}
+
+// DecryptShare reverses EncryptSharesFor, recovering the (s1, s2) pair
+// dealt to this node using its ElGamal private key myPriv.
+func (n *node) DecryptShare(es *EncryptedShare, myPriv kyber.Scalar) (s1, s2 kyber.Scalar, err error) {
+	S1 := n.curve.Point().Mul(myPriv, es.K1)
+	b1, err := n.curve.Point().Sub(es.C1, S1).Data()
+	if err != nil {
+		return nil, nil, fmt.Errorf("dkg: could not decrypt share chunk 1: %w", err)
+	}
+	S2 := n.curve.Point().Mul(myPriv, es.K2)
+	b2, err := n.curve.Point().Sub(es.C2, S2).Data()
+	if err != nil {
+		return nil, nil, fmt.Errorf("dkg: could not decrypt share chunk 2: %w", err)
+	}
+
+	s1 = n.curve.Scalar()
+	if err := s1.UnmarshalBinary(b1); err != nil {
+		return nil, nil, fmt.Errorf("dkg: could not unmarshal share1: %w", err)
+	}
+	s2 = n.curve.Scalar()
+	if err := s2.UnmarshalBinary(b2); err != nil {
+		return nil, nil, fmt.Errorf("dkg: could not unmarshal share2: %w", err)
+	}
+	return s1, s2, nil
+}
@@ -0,0 +1,107 @@
+package dkg
+
+import "testing"
+
+func TestEncryptSharesForRoundTrip(t *testing.T) {
+	curve, g2, _, _, dealerID, poly1, poly2 := getValidNodeParamsForTesting(t)
+	recipientID := curve.Scalar().SetInt64(54321)
+	recipientPriv := curve.Scalar().SetInt64(98765)
+	recipientPub := curve.Point().Mul(recipientPriv, nil)
+
+	dealer := newQualTestNode(t, curve, g2, dealerID, poly1, poly2)
+
+	es, err := dealer.EncryptSharesFor(recipientPub, recipientID)
+	if err != nil {
+		t.Fatalf("EncryptSharesFor failed: %v", err)
+	}
+	if !es.From.Equal(dealerID) || !es.To.Equal(recipientID) {
+		t.Fatalf("unexpected From/To on encrypted share: %v/%v", es.From, es.To)
+	}
+
+	s1, s2, err := dealer.DecryptShare(es, recipientPriv)
+	if err != nil {
+		t.Fatalf("DecryptShare failed: %v", err)
+	}
+
+	wantS1, wantS2 := dealer.EvaluatePolynomials(recipientID)
+	if !s1.Equal(wantS1) || !s2.Equal(wantS2) {
+		t.Errorf("decrypted shares do not match the cleartext ones:\ngot  (%v, %v)\nwant (%v, %v)", s1, s2, wantS1, wantS2)
+	}
+}
+
+func TestEncryptSharesForParticipantUsesRecordedPubKey(t *testing.T) {
+	curve, g2, _, _, dealerID, poly1, poly2 := getValidNodeParamsForTesting(t)
+	recipientID := curve.Scalar().SetInt64(54321)
+	recipientPriv := curve.Scalar().SetInt64(98765)
+	recipientPub := curve.Point().Mul(recipientPriv, nil)
+
+	dealer := newQualTestNode(t, curve, g2, dealerID, poly1, poly2)
+
+	if _, err := dealer.EncryptSharesForParticipant(recipientID); err == nil {
+		t.Fatalf("expected EncryptSharesForParticipant to fail before the recipient is on file")
+	}
+
+	participant := Participant{id: recipientID, PubKey: recipientPub}
+	dealer.otherParticipants = append(dealer.otherParticipants, participant)
+
+	es, err := dealer.EncryptSharesForParticipant(recipientID)
+	if err != nil {
+		t.Fatalf("EncryptSharesForParticipant failed: %v", err)
+	}
+
+	s1, s2, err := dealer.DecryptShare(es, recipientPriv)
+	if err != nil {
+		t.Fatalf("DecryptShare failed: %v", err)
+	}
+
+	wantS1, wantS2 := dealer.EvaluatePolynomials(recipientID)
+	if !s1.Equal(wantS1) || !s2.Equal(wantS2) {
+		t.Errorf("decrypted shares do not match the cleartext ones:\ngot  (%v, %v)\nwant (%v, %v)", s1, s2, wantS1, wantS2)
+	}
+}
+
+func TestEncryptSharesForUsesIndependentMasks(t *testing.T) {
+	curve, g2, _, _, dealerID, poly1, poly2 := getValidNodeParamsForTesting(t)
+	recipientID := curve.Scalar().SetInt64(54321)
+	recipientPriv := curve.Scalar().SetInt64(98765)
+	recipientPub := curve.Point().Mul(recipientPriv, nil)
+
+	dealer := newQualTestNode(t, curve, g2, dealerID, poly1, poly2)
+
+	es, err := dealer.EncryptSharesFor(recipientPub, recipientID)
+	if err != nil {
+		t.Fatalf("EncryptSharesFor failed: %v", err)
+	}
+
+	if es.K1.Equal(es.K2) {
+		t.Errorf("expected chunk 1 and chunk 2 to be masked under independent ephemeral keys, got the same K1/K2")
+	}
+
+	scalarLen := curve.Scalar().MarshalSize()
+	if max := curve.Point().EmbedLen(); scalarLen > max {
+		t.Fatalf("a marshaled scalar (%d bytes) does not fit in one point (EmbedLen %d); EncryptSharesFor should have failed", scalarLen, max)
+	}
+}
+
+func TestDecryptShareWrongKeyFails(t *testing.T) {
+	curve, g2, _, _, dealerID, poly1, poly2 := getValidNodeParamsForTesting(t)
+	recipientID := curve.Scalar().SetInt64(54321)
+	recipientPriv := curve.Scalar().SetInt64(98765)
+	recipientPub := curve.Point().Mul(recipientPriv, nil)
+
+	dealer := newQualTestNode(t, curve, g2, dealerID, poly1, poly2)
+
+	es, err := dealer.EncryptSharesFor(recipientPub, recipientID)
+	if err != nil {
+		t.Fatalf("EncryptSharesFor failed: %v", err)
+	}
+
+	wrongPriv := curve.Scalar().SetInt64(11111)
+	s1, s2, err := dealer.DecryptShare(es, wrongPriv)
+	if err == nil {
+		wantS1, wantS2 := dealer.EvaluatePolynomials(recipientID)
+		if s1.Equal(wantS1) && s2.Equal(wantS2) {
+			t.Errorf("decrypting with the wrong private key recovered the real shares")
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package dkg
+
+import (
+	"fmt"
+
+	"github.com/dedis/kyber"
+)
+
+// InvalidCurvePointError is returned when a caller supplies a curve point
+// that cannot be used as the second Pedersen generator, e.g. the identity
+// element.
+type InvalidCurvePointError struct {
+	Point kyber.Point
+}
+
+func (e InvalidCurvePointError) Error() string {
+	return fmt.Sprintf("dkg: invalid curve point %v", e.Point)
+}
+
+// InvalidPolynomialError is returned when the secret polynomials supplied to
+// NewNode are empty or do not share the same degree.
+type InvalidPolynomialError struct {
+	Reason string
+}
+
+func (e InvalidPolynomialError) Error() string {
+	return fmt.Sprintf("dkg: invalid secret polynomial: %s", e.Reason)
+}
+
+// ParticipantNotFoundError is returned when ProcessSecretShareVerification is
+// asked to verify a participant id that was never added to the node's
+// participant list.
+type ParticipantNotFoundError struct {
+	ID kyber.Scalar
+}
+
+func (e ParticipantNotFoundError) Error() string {
+	return fmt.Sprintf("dkg: participant %v not found", e.ID)
+}
+
+// SharesVerifiedError is returned by RaiseComplaint when the accused
+// participant's shares in fact verify correctly against its published
+// commitments, so there is nothing to complain about.
+type SharesVerifiedError struct {
+	Accused kyber.Scalar
+}
+
+func (e SharesVerifiedError) Error() string {
+	return fmt.Sprintf("dkg: refusing to raise a complaint against %v: its shares verify correctly", e.Accused)
+}
+
+// MisdirectedComplaintError is returned by ProcessComplaint when a Complaint
+// accuses a node other than the one processing it.
+type MisdirectedComplaintError struct {
+	Accused kyber.Scalar
+	Self    kyber.Scalar
+}
+
+func (e MisdirectedComplaintError) Error() string {
+	return fmt.Sprintf("dkg: complaint accuses %v, not this node (%v)", e.Accused, e.Self)
+}
+
+// ShareTooLargeError is returned by EncryptSharesFor when a marshaled secret
+// share does not fit within a single curve point's embedding capacity, so
+// there is no way to seal it without either truncating it or leaking the
+// overflow in the clear.
+type ShareTooLargeError struct {
+	Len int
+	Max int
+}
+
+func (e ShareTooLargeError) Error() string {
+	return fmt.Sprintf("dkg: marshaled share is %d bytes, exceeds embedding capacity of %d", e.Len, e.Max)
+}
@@ -0,0 +1,55 @@
+package dkg
+
+import "github.com/dedis/kyber"
+
+// FeldmanVSS implements Feldman's verifiable secret sharing scheme:
+// commitments C_j = g^{a_j} over a single secret polynomial. Each participant
+// is dealt one share instead of PedersenVSS's two, and the group public key
+// part can be read
+// straight off C_0, at the cost of only computational (not
+// information-theoretic) hiding of the shares.
+type FeldmanVSS struct {
+	curve kyber.Group
+}
+
+// NewFeldmanVSS constructs a Feldman VSS scheme over curve.
+func NewFeldmanVSS(curve kyber.Group) *FeldmanVSS {
+	return &FeldmanVSS{curve: curve}
+}
+
+// Valid requires exactly one non-empty secret polynomial.
+func (v *FeldmanVSS) Valid(polys []ScalarPolynomial) error {
+	if len(polys) != 1 {
+		return InvalidPolynomialError{Reason: "feldman VSS requires exactly one secret polynomial"}
+	}
+	if len(polys[0]) == 0 {
+		return InvalidPolynomialError{Reason: "secret polynomial must not be empty"}
+	}
+	return nil
+}
+
+// Commit returns the Feldman commitments to polys[0].
+func (v *FeldmanVSS) Commit(polys []ScalarPolynomial) PointTuple {
+	poly := polys[0]
+	points := make(PointTuple, len(poly))
+	for i := range poly {
+		points[i] = v.curve.Point().Mul(poly[i], nil)
+	}
+	return points
+}
+
+// Verify checks g^{shares[0]} == Sum_j C_j^{id^j}.
+func (v *FeldmanVSS) Verify(id kyber.Scalar, shares []kyber.Scalar, C PointTuple) bool {
+	lhs := v.curve.Point().Mul(shares[0], nil)
+	return lhs.Equal(EvalExp(v.curve, C, id))
+}
+
+// Reveal returns C_0 = g^{a_0} directly.
+func (v *FeldmanVSS) Reveal(polys []ScalarPolynomial) kyber.Point {
+	return v.curve.Point().Mul(polys[0][0], nil)
+}
+
+// NumPolynomials returns 1: Feldman deals a single secret polynomial.
+func (v *FeldmanVSS) NumPolynomials() int {
+	return 1
+}
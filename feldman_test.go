@@ -0,0 +1,130 @@
+package dkg
+
+import (
+	"testing"
+
+	"github.com/dedis/kyber/pairing/bn256"
+)
+
+func TestGenerateNodeFeldman(t *testing.T) {
+	curve, g2, zkParam, timeout, id, _, _ := getValidNodeParamsForTesting(t)
+	threshold := 4
+
+	n, err := GenerateNode(curve, g2, zkParam, timeout, id, bn256.NewSuite().RandomStream(), threshold, NewFeldmanVSS(curve))
+	if err != nil {
+		t.Fatalf("GenerateNode failed: %v", err)
+	}
+
+	points := n.VerificationPoints()
+	if len(points) != threshold {
+		t.Fatalf("got %d verification points, want %d", len(points), threshold)
+	}
+	if !n.PublicKeyPart().Equal(points[0]) {
+		t.Errorf("PublicKeyPart does not match the Feldman commitment to the constant term")
+	}
+}
+
+func TestProcessSecretShareVerificationFeldman(t *testing.T) {
+	curve, g2, _, _, dealerID, dealerPoly1, _ := getValidNodeParamsForTesting(t)
+	accuserID := curve.Scalar().SetInt64(54321)
+
+	dealer, err := NewNode(curve, g2, curve.Scalar().Zero(), 0, dealerID, dealerPoly1, nil, NewFeldmanVSS(curve))
+	if err != nil {
+		t.Fatalf("could not construct dealer: %v", err)
+	}
+	accuser, err := NewNode(curve, g2, curve.Scalar().Zero(), 0, accuserID, dealerPoly1, nil, NewFeldmanVSS(curve))
+	if err != nil {
+		t.Fatalf("could not construct accuser: %v", err)
+	}
+
+	share1, share2 := dealer.EvaluatePolynomials(accuserID)
+	addParticipantToNodeList(accuser, dealerID, share1, share2, dealer.VerificationPoints(), dealer.PublicKeyPart())
+
+	verified, err := accuser.ProcessSecretShareVerification(dealerID)
+	if !verified || err != nil {
+		t.Errorf("expected a genuine Feldman share to verify, got verified=%v err=%v", verified, err)
+	}
+
+	badShare1 := curve.Scalar().SetInt64(1)
+	addParticipantToNodeList(accuser, dealerID, badShare1, share2, dealer.VerificationPoints(), dealer.PublicKeyPart())
+
+	verified, err = accuser.ProcessSecretShareVerification(dealerID)
+	if verified || err != nil {
+		t.Errorf("expected a tampered Feldman share to fail verification, got verified=%v err=%v", verified, err)
+	}
+}
+
+func TestGroupPublicKeyFeldman(t *testing.T) {
+	curve, g2, _, _, _, _, _ := getValidNodeParamsForTesting(t)
+
+	aID := curve.Scalar().SetInt64(1)
+	bID := curve.Scalar().SetInt64(2)
+	aPoly := ScalarPolynomial{curve.Scalar().SetInt64(10), curve.Scalar().SetInt64(11)}
+	bPoly := ScalarPolynomial{curve.Scalar().SetInt64(20), curve.Scalar().SetInt64(21)}
+
+	a, err := NewNode(curve, g2, curve.Scalar().Zero(), 0, aID, aPoly, nil, NewFeldmanVSS(curve))
+	if err != nil {
+		t.Fatalf("could not construct node a: %v", err)
+	}
+	b, err := NewNode(curve, g2, curve.Scalar().Zero(), 0, bID, bPoly, nil, NewFeldmanVSS(curve))
+	if err != nil {
+		t.Fatalf("could not construct node b: %v", err)
+	}
+
+	addParticipantToNodeList(a, bID, curve.Scalar().Zero(), curve.Scalar().Zero(), b.VerificationPoints(), b.PublicKeyPart())
+
+	want := curve.Point().Add(a.PublicKeyPart(), b.PublicKeyPart())
+	if !a.GroupPublicKey().Equal(want) {
+		t.Errorf("GroupPublicKey did not aggregate every participant's PublicKeyPart")
+	}
+}
+
+func TestFeldmanVSSValidRejectsMalformedPolynomials(t *testing.T) {
+	curve := bn256.NewSuite().G1()
+	v := NewFeldmanVSS(curve)
+
+	poly := ScalarPolynomial{curve.Scalar().SetInt64(1), curve.Scalar().SetInt64(2)}
+
+	cases := []struct {
+		name  string
+		polys []ScalarPolynomial
+	}{
+		{"no polynomials", []ScalarPolynomial{}},
+		{"two polynomials", []ScalarPolynomial{poly, poly}},
+		{"empty polynomial", []ScalarPolynomial{{}}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := v.Valid(c.polys); err == nil {
+				t.Errorf("expected %v to be rejected", c.polys)
+			}
+		})
+	}
+}
+
+func TestPedersenVSSValidRejectsMalformedPolynomials(t *testing.T) {
+	curve := bn256.NewSuite().G1()
+	g2 := curve.Point().Base()
+	g2.Mul(curve.Scalar().SetInt64(42), g2)
+	v := NewPedersenVSS(curve, g2)
+
+	poly := ScalarPolynomial{curve.Scalar().SetInt64(1), curve.Scalar().SetInt64(2)}
+	shortPoly := ScalarPolynomial{curve.Scalar().SetInt64(1)}
+
+	cases := []struct {
+		name  string
+		polys []ScalarPolynomial
+	}{
+		{"one polynomial", []ScalarPolynomial{poly}},
+		{"three polynomials", []ScalarPolynomial{poly, poly, poly}},
+		{"empty polynomial", []ScalarPolynomial{{}, poly}},
+		{"mismatched degree", []ScalarPolynomial{poly, shortPoly}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := v.Valid(c.polys); err == nil {
+				t.Errorf("expected %v to be rejected", c.polys)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Package dkgtest provides shared fixtures for tests in packages that build
+// on DKG node output (tdss, tenc), so each doesn't maintain its own copy of
+// the same "deal a VSS-backed node and evaluate it at a set of indices"
+// setup.
+package dkgtest
+
+import (
+	"testing"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/util/random"
+	"github.com/vaporyorg/dkg"
+)
+
+// DealShares builds a single DKG node (via dkg.GenerateNode) around a freshly sampled secret
+// polynomial for the given VSS scheme and evaluates it at every index in
+// indices, returning its verification points, each participant's pair of
+// shares (the second share is the curve's zero scalar under FeldmanVSS),
+// and the group public key.
+func DealShares(
+	t *testing.T,
+	curve kyber.Group,
+	g2 kyber.Point,
+	vss dkg.VSS,
+	indices []kyber.Scalar,
+) (verificationPoints dkg.PointTuple, shares1, shares2 []kyber.Scalar, groupPub kyber.Point) {
+	t.Helper()
+
+	threshold := len(indices)
+	dealerID := curve.Scalar().SetInt64(999)
+
+	node, err := dkg.GenerateNode(curve, g2, curve.Scalar().Zero(), 0, dealerID, random.New(), threshold, vss)
+	if err != nil {
+		t.Fatalf("could not construct dealer node: %v", err)
+	}
+
+	shares1 = make([]kyber.Scalar, len(indices))
+	shares2 = make([]kyber.Scalar, len(indices))
+	for i, idx := range indices {
+		shares1[i], shares2[i] = node.EvaluatePolynomials(idx)
+	}
+
+	return node.VerificationPoints(), shares1, shares2, node.PublicKeyPart()
+}
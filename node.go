@@ -0,0 +1,217 @@
+// Package dkg implements a verifiable-secret-sharing distributed key
+// generation protocol over a kyber group, with a pluggable VSS scheme
+// (PedersenVSS or FeldmanVSS).
+package dkg
+
+import (
+	"crypto/cipher"
+	"time"
+
+	"github.com/dedis/kyber"
+)
+
+// ScalarPolynomial represents a polynomial over a group's scalar field by
+// its coefficients in order of increasing degree, i.e. poly[i] is the
+// coefficient of x^i.
+type ScalarPolynomial []kyber.Scalar
+
+// PointTuple is an ordered list of VSS commitments (Pedersen or Feldman) to
+// the coefficients of a ScalarPolynomial, one point per coefficient.
+type PointTuple []kyber.Point
+
+// Participant is what a node records about another party in the DKG: the
+// shares it claims to have dealt this node and the commitments to verify
+// them against.
+type Participant struct {
+	id                 kyber.Scalar
+	secretShare1       kyber.Scalar
+	secretShare2       kyber.Scalar
+	verificationPoints PointTuple
+
+	// PublicKeyPart is the participant's own PublicKeyPart(), as it
+	// broadcasts it alongside its verification points. It must be tracked
+	// separately from verificationPoints[0]: under PedersenVSS that
+	// commitment is blinded by g2, so it cannot be folded into
+	// GroupPublicKey directly the way a Feldman commitment can.
+	PublicKeyPart kyber.Point
+
+	// PubKey is the participant's ElGamal encryption key, used to address it
+	// with EncryptSharesFor (see EncryptSharesForParticipant) once shares are
+	// dealt over an untrusted transport instead of handed to
+	// EvaluatePolynomials directly.
+	PubKey kyber.Point
+}
+
+// node is a single participant in the DKG. It holds its own secret
+// polynomials (two under PedersenVSS, its binding and hiding components; one
+// under FeldmanVSS, with secretPoly2 left empty) and whatever it has learned
+// about the other participants.
+type node struct {
+	curve   kyber.Group
+	g2      kyber.Point
+	zkParam kyber.Scalar
+	timeout time.Duration
+
+	id          kyber.Scalar
+	secretPoly1 ScalarPolynomial
+	secretPoly2 ScalarPolynomial
+	vss         VSS
+
+	otherParticipants []Participant
+
+	// Disqualified holds the ids of participants whose complaints were
+	// justified by ProcessJustification; GroupPublicKey excludes them.
+	Disqualified []kyber.Scalar
+
+	// epoch is the highest proactive resharing epoch this node has applied,
+	// via Reshare or ProcessReshare. It starts at zero before any reshare.
+	epoch uint64
+
+	// updateCommitments accumulates the commitments to every update
+	// polynomial this node has issued via Reshare, one sum per coefficient,
+	// so VerificationPoints keeps reporting this node's current, post-reshare
+	// commitments rather than the ones from its original secret polynomial.
+	updateCommitments PointTuple
+}
+
+// NewNode constructs a node from one or two already-chosen secret
+// polynomials, depending on what vss requires. g2 must be a generator
+// independent of the curve's base point; it is only consumed by VSS schemes
+// that need a second generator (e.g. PedersenVSS).
+func NewNode(
+	curve kyber.Group,
+	g2 kyber.Point,
+	zkParam kyber.Scalar,
+	timeout time.Duration,
+	id kyber.Scalar,
+	secretPoly1 ScalarPolynomial,
+	secretPoly2 ScalarPolynomial,
+	vss VSS,
+) (*node, error) {
+	if g2 == nil || g2.Equal(curve.Point().Null()) {
+		return nil, InvalidCurvePointError{Point: g2}
+	}
+	if err := vss.Valid(pruneEmpty(secretPoly1, secretPoly2)); err != nil {
+		return nil, err
+	}
+
+	return &node{
+		curve:   curve,
+		g2:      g2,
+		zkParam: zkParam,
+		timeout: timeout,
+
+		id:          id,
+		secretPoly1: secretPoly1,
+		secretPoly2: secretPoly2,
+		vss:         vss,
+	}, nil
+}
+
+// pruneEmpty drops secretPoly2 from the set handed to a VSS scheme's Valid
+// and Commit methods when it is empty, so single-polynomial schemes like
+// FeldmanVSS see exactly the inputs they expect.
+func pruneEmpty(secretPoly1, secretPoly2 ScalarPolynomial) []ScalarPolynomial {
+	if len(secretPoly2) == 0 {
+		return []ScalarPolynomial{secretPoly1}
+	}
+	return []ScalarPolynomial{secretPoly1, secretPoly2}
+}
+
+// GenerateNode constructs a node around a freshly sampled set of secret
+// polynomials of degree threshold-1, read from rand. Pedersen-style VSS
+// schemes consume two such polynomials; Feldman-style schemes only need one,
+// so the second is left empty.
+func GenerateNode(
+	curve kyber.Group,
+	g2 kyber.Point,
+	zkParam kyber.Scalar,
+	timeout time.Duration,
+	id kyber.Scalar,
+	rand cipher.Stream,
+	threshold int,
+	vss VSS,
+) (*node, error) {
+	secretPoly1 := make(ScalarPolynomial, threshold)
+	for i := 0; i < threshold; i++ {
+		secretPoly1[i] = curve.Scalar().Pick(rand)
+	}
+
+	var secretPoly2 ScalarPolynomial
+	if vss.NumPolynomials() > 1 {
+		secretPoly2 = make(ScalarPolynomial, threshold)
+		for i := 0; i < threshold; i++ {
+			secretPoly2[i] = curve.Scalar().Pick(rand)
+		}
+	}
+
+	return NewNode(curve, g2, zkParam, timeout, id, secretPoly1, secretPoly2, vss)
+}
+
+// PublicKeyPart returns this node's contribution to the group public key,
+// as determined by its VSS scheme.
+func (n *node) PublicKeyPart() kyber.Point {
+	return n.vss.Reveal(pruneEmpty(n.secretPoly1, n.secretPoly2))
+}
+
+// VerificationPoints returns the VSS commitments to the coefficients of this
+// node's secret polynomial(s), in order of increasing degree, folding in the
+// commitments to every update polynomial issued by a prior call to Reshare
+// so the result always reflects this node's current epoch.
+func (n *node) VerificationPoints() PointTuple {
+	points := n.vss.Commit(pruneEmpty(n.secretPoly1, n.secretPoly2))
+	for i, p := range n.updateCommitments {
+		points[i] = n.curve.Point().Add(points[i], p)
+	}
+	return points
+}
+
+// EvaluatePolynomials evaluates this node's secret polynomials at id,
+// returning the pair of shares to be dealt to the participant identified by
+// id. The second share is the curve's zero scalar when the node has no
+// second polynomial, as under FeldmanVSS.
+func (n *node) EvaluatePolynomials(id kyber.Scalar) (kyber.Scalar, kyber.Scalar) {
+	share2 := n.curve.Scalar().Zero()
+	if len(n.secretPoly2) > 0 {
+		share2 = evaluatePolynomial(n.curve, n.secretPoly2, id)
+	}
+	return evaluatePolynomial(n.curve, n.secretPoly1, id), share2
+}
+
+func evaluatePolynomial(curve kyber.Group, poly ScalarPolynomial, x kyber.Scalar) kyber.Scalar {
+	result := curve.Scalar().Zero()
+	xPow := curve.Scalar().One()
+	for _, coeff := range poly {
+		result.Add(result, curve.Scalar().Mul(coeff, xPow))
+		xPow.Mul(xPow, x)
+	}
+	return result
+}
+
+// ProcessSecretShareVerification checks the shares dealt by the participant
+// identified by participantID against the VSS commitments it published,
+// returning whether the shares are consistent. The commitments are
+// evaluated at this node's own id, since that is the point at which the
+// dealer's polynomials were evaluated to produce the shares dealt to it.
+func (n *node) ProcessSecretShareVerification(participantID kyber.Scalar) (bool, error) {
+	participant, err := n.findParticipant(participantID)
+	if err != nil {
+		return false, err
+	}
+
+	shares := []kyber.Scalar{participant.secretShare1}
+	if n.vss.NumPolynomials() > 1 {
+		shares = append(shares, participant.secretShare2)
+	}
+
+	return n.vss.Verify(n.id, shares, participant.verificationPoints), nil
+}
+
+func (n *node) findParticipant(id kyber.Scalar) (*Participant, error) {
+	for i := range n.otherParticipants {
+		if n.otherParticipants[i].id.Equal(id) {
+			return &n.otherParticipants[i], nil
+		}
+	}
+	return nil, ParticipantNotFoundError{ID: id}
+}
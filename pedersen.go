@@ -0,0 +1,63 @@
+package dkg
+
+import "github.com/dedis/kyber"
+
+// PedersenVSS implements Pedersen's information-theoretically hiding
+// verifiable secret sharing scheme: commitments C_j = g^{a_j} * g2^{b_j} over
+// a pair of secret polynomials.
+type PedersenVSS struct {
+	curve kyber.Group
+	g2    kyber.Point
+}
+
+// NewPedersenVSS constructs a Pedersen VSS scheme using g2 as the second
+// generator, which must be chosen so its discrete log relative to the
+// curve's base point is unknown.
+func NewPedersenVSS(curve kyber.Group, g2 kyber.Point) *PedersenVSS {
+	return &PedersenVSS{curve: curve, g2: g2}
+}
+
+// Valid requires exactly two non-empty secret polynomials of equal degree.
+func (v *PedersenVSS) Valid(polys []ScalarPolynomial) error {
+	if len(polys) != 2 {
+		return InvalidPolynomialError{Reason: "pedersen VSS requires exactly two secret polynomials"}
+	}
+	if len(polys[0]) == 0 || len(polys[1]) == 0 {
+		return InvalidPolynomialError{Reason: "secret polynomials must not be empty"}
+	}
+	if len(polys[0]) != len(polys[1]) {
+		return InvalidPolynomialError{Reason: "secret polynomials must have the same degree"}
+	}
+	return nil
+}
+
+// Commit returns the Pedersen commitments to polys[0] and polys[1].
+func (v *PedersenVSS) Commit(polys []ScalarPolynomial) PointTuple {
+	poly1, poly2 := polys[0], polys[1]
+	points := make(PointTuple, len(poly1))
+	for i := range poly1 {
+		c := v.curve.Point().Mul(poly1[i], nil)
+		c.Add(c, v.curve.Point().Mul(poly2[i], v.g2))
+		points[i] = c
+	}
+	return points
+}
+
+// Verify checks g^{shares[0]} * g2^{shares[1]} == Sum_j C_j^{id^j}.
+func (v *PedersenVSS) Verify(id kyber.Scalar, shares []kyber.Scalar, C PointTuple) bool {
+	lhs := v.curve.Point().Mul(shares[0], nil)
+	lhs.Add(lhs, v.curve.Point().Mul(shares[1], v.g2))
+
+	return lhs.Equal(EvalExp(v.curve, C, id))
+}
+
+// Reveal returns g^{a_0}, ignoring the hiding polynomial's constant term.
+func (v *PedersenVSS) Reveal(polys []ScalarPolynomial) kyber.Point {
+	return v.curve.Point().Mul(polys[0][0], nil)
+}
+
+// NumPolynomials returns 2: Pedersen deals a hiding polynomial alongside the
+// binding one.
+func (v *PedersenVSS) NumPolynomials() int {
+	return 2
+}
@@ -0,0 +1,174 @@
+package dkg
+
+import (
+	"crypto/cipher"
+	"fmt"
+
+	"github.com/dedis/kyber"
+)
+
+// ResharePackage is what a node broadcasts when refreshing its shares for a
+// new epoch: a fresh Herzberg-style update polynomial δ, with δ(0)=0 so the
+// group public key is unaffected, committed via the configured VSS scheme,
+// together with every peer's evaluation of it.
+type ResharePackage struct {
+	From               kyber.Scalar
+	Epoch              uint64
+	VerificationPoints PointTuple
+	Shares             []ResharedShare
+}
+
+// ResharedShare is one peer's evaluation of a ResharePackage's update
+// polynomial, dealt to the participant identified by To exactly as
+// EvaluatePolynomials deals the original DKG shares.
+type ResharedShare struct {
+	To     kyber.Scalar
+	Share1 kyber.Scalar
+	Share2 kyber.Scalar
+}
+
+// MissingResharedShareError is returned by ProcessReshare when a
+// ResharePackage carries no share addressed to this node.
+type MissingResharedShareError struct {
+	From kyber.Scalar
+	To   kyber.Scalar
+}
+
+func (e MissingResharedShareError) Error() string {
+	return fmt.Sprintf("dkg: reshare package from %v has no share for %v", e.From, e.To)
+}
+
+// InvalidReshareError is returned by ProcessReshare when the share a
+// ResharePackage addresses to this node does not verify against the
+// package's own VerificationPoints.
+type InvalidReshareError struct {
+	From kyber.Scalar
+}
+
+func (e InvalidReshareError) Error() string {
+	return fmt.Sprintf("dkg: invalid reshare package from participant %v", e.From)
+}
+
+// Reshare implements one round of Herzberg-style proactive secret sharing.
+// It samples a fresh update polynomial δ (and, under PedersenVSS, a second
+// one) of the same degree as this node's original secret polynomial, but
+// with constant term zero so the group secret, and hence GroupPublicKey, is
+// unaffected, commits to it with the configured VSS scheme, and evaluates it
+// at every other participant this node knows about. Once every node's
+// package for epoch has been processed by ProcessReshare, an adversary who
+// compromised fewer than threshold shares from an earlier epoch can no
+// longer combine them with shares from this one.
+func (n *node) Reshare(epoch uint64, rand cipher.Stream) (*ResharePackage, error) {
+	size := len(n.secretPoly1)
+	delta1 := newZeroConstantPolynomial(n.curve, size, rand)
+
+	var delta2 ScalarPolynomial
+	if n.vss.NumPolynomials() > 1 {
+		delta2 = newZeroConstantPolynomial(n.curve, size, rand)
+	}
+
+	points := n.vss.Commit(pruneEmpty(delta1, delta2))
+
+	shares := make([]ResharedShare, len(n.otherParticipants))
+	for i, p := range n.otherParticipants {
+		share2 := n.curve.Scalar().Zero()
+		if len(delta2) > 0 {
+			share2 = evaluatePolynomial(n.curve, delta2, p.id)
+		}
+		shares[i] = ResharedShare{
+			To:     p.id,
+			Share1: evaluatePolynomial(n.curve, delta1, p.id),
+			Share2: share2,
+		}
+	}
+
+	n.accumulateOwnCommitments(points)
+	if epoch > n.epoch {
+		n.epoch = epoch
+	}
+
+	return &ResharePackage{
+		From:               n.id,
+		Epoch:              epoch,
+		VerificationPoints: points,
+		Shares:             shares,
+	}, nil
+}
+
+// newZeroConstantPolynomial samples a random degree-(size-1) polynomial
+// whose constant term is zero, as Reshare needs for an update that leaves
+// the group secret unchanged.
+func newZeroConstantPolynomial(curve kyber.Group, size int, rand cipher.Stream) ScalarPolynomial {
+	poly := make(ScalarPolynomial, size)
+	poly[0] = curve.Scalar().Zero()
+	for i := 1; i < size; i++ {
+		poly[i] = curve.Scalar().Pick(rand)
+	}
+	return poly
+}
+
+// accumulateOwnCommitments folds a newly issued update polynomial's
+// commitments into the running total VerificationPoints reports, keeping
+// this node's own published commitments in step with the updates its peers
+// are folding into their copy of them via ProcessReshare.
+func (n *node) accumulateOwnCommitments(points PointTuple) {
+	if n.updateCommitments == nil {
+		n.updateCommitments = make(PointTuple, len(points))
+		for i := range n.updateCommitments {
+			n.updateCommitments[i] = n.curve.Point().Null()
+		}
+	}
+	for i, p := range points {
+		n.updateCommitments[i] = n.curve.Point().Add(n.updateCommitments[i], p)
+	}
+}
+
+// ProcessReshare applies every ResharePackage in pkgs, one from each
+// participant refreshing its shares for a new epoch, folding each update
+// share into the corresponding dealer's recorded share and verification
+// points and advancing this node's epoch counter to the highest epoch seen.
+// It returns InvalidReshareError for the first package whose update share
+// does not verify, leaving any packages after it unapplied.
+func (n *node) ProcessReshare(pkgs []*ResharePackage) error {
+	for _, pkg := range pkgs {
+		participant, err := n.findParticipant(pkg.From)
+		if err != nil {
+			return err
+		}
+
+		share, err := findResharedShare(pkg, n.id)
+		if err != nil {
+			return err
+		}
+
+		shares := []kyber.Scalar{share.Share1}
+		if n.vss.NumPolynomials() > 1 {
+			shares = append(shares, share.Share2)
+		}
+		if !n.vss.Verify(n.id, shares, pkg.VerificationPoints) {
+			return InvalidReshareError{From: pkg.From}
+		}
+
+		participant.secretShare1.Add(participant.secretShare1, share.Share1)
+		if n.vss.NumPolynomials() > 1 {
+			participant.secretShare2.Add(participant.secretShare2, share.Share2)
+		}
+		for i, p := range pkg.VerificationPoints {
+			participant.verificationPoints[i] = n.curve.Point().Add(participant.verificationPoints[i], p)
+		}
+
+		if pkg.Epoch > n.epoch {
+			n.epoch = pkg.Epoch
+		}
+	}
+	return nil
+}
+
+func findResharedShare(pkg *ResharePackage, id kyber.Scalar) (*ResharedShare, error) {
+	for i := range pkg.Shares {
+		if pkg.Shares[i].To.Equal(id) {
+			return &pkg.Shares[i], nil
+		}
+	}
+	return nil, MissingResharedShareError{From: pkg.From, To: id}
+}
@@ -0,0 +1,134 @@
+package dkg
+
+import (
+	"testing"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/util/random"
+)
+
+func TestProactiveReshareAcrossEpochs(t *testing.T) {
+	curve, g2, zkParam, timeout, _, poly1, poly2 := getValidNodeParamsForTesting(t)
+	threshold := len(poly1) // 4
+	quorum := threshold + 1 // 5 participants, all mutually dealing to each other
+
+	var dealers []*node
+	for i := int64(0); i < int64(quorum); i++ {
+		id := curve.Scalar().SetInt64(3000 + i)
+		dealer, err := NewNode(curve, g2, zkParam, timeout, id, poly1, poly2, NewPedersenVSS(curve, g2))
+		if err != nil {
+			t.Fatalf("could not construct node %v: %v", id, err)
+		}
+		dealers = append(dealers, dealer)
+	}
+
+	for _, observer := range dealers {
+		for _, dealer := range dealers {
+			if dealer.id.Equal(observer.id) {
+				continue
+			}
+			share1, share2 := dealer.EvaluatePolynomials(observer.id)
+			addParticipantToNodeList(observer, dealer.id, share1, share2, dealer.VerificationPoints(), dealer.PublicKeyPart())
+		}
+	}
+
+	originalKey := dealers[0].GroupPublicKey()
+	for _, d := range dealers[1:] {
+		if !d.GroupPublicKey().Equal(originalKey) {
+			t.Fatalf("group public keys disagree before any resharing")
+		}
+	}
+
+	// Leak dealers[1]'s record of dealers[0]'s share, once before any
+	// resharing (epoch 0) and again after epoch 2 — the same dealer and the
+	// same observer, captured at two different epochs.
+	leaked0 := snapshotShare(t, dealers[1], dealers[0].id)
+	var leaked2 Participant
+
+	for epoch := uint64(1); epoch <= 3; epoch++ {
+		packages := make([]*ResharePackage, len(dealers))
+		for i, dealer := range dealers {
+			pkg, err := dealer.Reshare(epoch, random.New())
+			if err != nil {
+				t.Fatalf("Reshare failed for %v at epoch %d: %v", dealer.id, epoch, err)
+			}
+			packages[i] = pkg
+		}
+
+		for _, observer := range dealers {
+			var incoming []*ResharePackage
+			for i, dealer := range dealers {
+				if dealer.id.Equal(observer.id) {
+					continue
+				}
+				incoming = append(incoming, packages[i])
+			}
+			if err := observer.ProcessReshare(incoming); err != nil {
+				t.Fatalf("ProcessReshare failed at epoch %d: %v", epoch, err)
+			}
+		}
+
+		if epoch == 2 {
+			leaked2 = snapshotShare(t, dealers[1], dealers[0].id)
+		}
+	}
+
+	if dealers[0].epoch != 3 {
+		t.Errorf("expected dealers[0] to be at epoch 3, got %d", dealers[0].epoch)
+	}
+
+	// The group public key is invariant across resharing: the constant term
+	// of every update polynomial is zero, so the group secret never moves.
+	for _, d := range dealers {
+		if !d.GroupPublicKey().Equal(originalKey) {
+			t.Errorf("node %v's group public key diverged after resharing", d.id)
+		}
+	}
+
+	// A current, legitimately-refreshed share still verifies against the
+	// dealer's current (epoch 3) verification points.
+	current, err := dealers[1].findParticipant(dealers[0].id)
+	if err != nil {
+		t.Fatalf("dealers[1] lost its record of dealers[0]: %v", err)
+	}
+	if !dealers[1].vss.Verify(dealers[1].id, []kyber.Scalar{current.secretShare1, current.secretShare2}, current.verificationPoints) {
+		t.Errorf("expected the current, refreshed share to verify against the current verification points")
+	}
+
+	// Neither of dealers[1]'s two leaked snapshots of dealers[0]'s share
+	// (epoch 0 and epoch 2) verifies against the current (epoch 3)
+	// verification points: each is stale on its own.
+	if dealers[1].vss.Verify(dealers[1].id, []kyber.Scalar{leaked0.secretShare1, leaked0.secretShare2}, current.verificationPoints) {
+		t.Errorf("expected the epoch-0 leaked share to no longer verify against the current verification points")
+	}
+	if dealers[1].vss.Verify(dealers[1].id, []kyber.Scalar{leaked2.secretShare1, leaked2.secretShare2}, current.verificationPoints) {
+		t.Errorf("expected the epoch-2 leaked share to no longer verify against the current verification points")
+	}
+
+	// And they are not interchangeable with each other either: proactive
+	// resharing replaced dealers[0]'s contribution to dealers[1] between
+	// epoch 0 and epoch 2, so an adversary who stole the epoch-0 copy gains
+	// nothing by also stealing the epoch-2 copy — they aren't shares of the
+	// same polynomial and averaging/substituting one for the other does not
+	// recover a usable share.
+	if leaked0.secretShare1.Equal(leaked2.secretShare1) {
+		t.Errorf("expected the same participant's share to change across epochs")
+	}
+}
+
+// snapshotShare copies out observer's currently recorded Participant entry
+// for dealerID, so a later reshare round mutating it in place does not also
+// mutate the "leaked" copy a test is holding onto.
+func snapshotShare(t *testing.T, observer *node, dealerID kyber.Scalar) Participant {
+	t.Helper()
+	p, err := observer.findParticipant(dealerID)
+	if err != nil {
+		t.Fatalf("could not find participant %v: %v", dealerID, err)
+	}
+	return Participant{
+		id:                 p.id,
+		secretShare1:       p.secretShare1.Clone(),
+		secretShare2:       p.secretShare2.Clone(),
+		verificationPoints: append(PointTuple(nil), p.verificationPoints...),
+	}
+}
@@ -0,0 +1,265 @@
+// Package tdss implements distributed Schnorr threshold signing on top of
+// the long-term shared secret produced by a DKG node, following Stinson and
+// Strobl's "Provably Secure Distributed Schnorr Signatures and a Threshold
+// Scheme for Implicit Certificates".
+package tdss
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/dedis/kyber"
+	"github.com/vaporyorg/dkg"
+)
+
+// Participant identifies a co-signer by its DKG index.
+type Participant struct {
+	Index kyber.Scalar
+}
+
+// PartialSig is one participant's contribution s_i = r_i + c*x_i (mod q) to
+// the aggregate Schnorr signature, tagged with the index it was produced by.
+// S2 is the PedersenVSS hiding-polynomial companion u_i = v_i + c*w_i,
+// always the curve's zero scalar under FeldmanVSS.
+type PartialSig struct {
+	Index kyber.Scalar
+	S     kyber.Scalar
+	S2    kyber.Scalar
+}
+
+// InvalidPartialSigError reports that a partial signature either came from
+// an index outside the session's quorum or failed the
+// g^{s_i} == R_i * Y_i^c check, naming the offending participant.
+type InvalidPartialSigError struct {
+	Index kyber.Scalar
+}
+
+func (e InvalidPartialSigError) Error() string {
+	return fmt.Sprintf("tdss: invalid partial signature from participant %v", e.Index)
+}
+
+// DSS drives one participant's side of a distributed Schnorr signature over
+// msg, combining a long-term DKG secret with a once-per-signature random
+// secret sharing.
+type DSS struct {
+	curve kyber.Group
+	g2    kyber.Point
+
+	index           kyber.Scalar
+	longTermShare   kyber.Scalar
+	longTermShare2  kyber.Scalar
+	longTermPubPoly dkg.PointTuple
+	randomShare     kyber.Scalar
+	randomShare2    kyber.Scalar
+	randomPubPoly   dkg.PointTuple
+
+	participants []Participant
+	t            int
+	msg          []byte
+
+	r         kyber.Point
+	challenge kyber.Scalar
+	partials  map[string]PartialSig
+}
+
+// NewDSS prepares a DSS session for the participant identified by index. g2
+// is the second Pedersen generator the DKG was run with, longTermShare/
+// longTermPubPoly are this node's share of and verification points for the
+// long-term group secret, and randomShare/randomPubPoly are the analogous
+// values for a fresh, per-signature random secret shared among the same
+// quorum.
+//
+// longTermShare2 and randomShare2 are the corresponding shares of the DKG's
+// second (hiding) polynomial under PedersenVSS, i.e. the second share a
+// dealer hands out from EvaluatePolynomials; pass the curve's zero scalar for
+// both when the DKG was run with FeldmanVSS, which has no second
+// polynomial. longTermPubPoly and randomPubPoly are node.VerificationPoints()
+// as published, so under PedersenVSS they already carry both polynomials'
+// commitments folded together one-per-coefficient; DSS never needs them
+// split apart.
+func NewDSS(
+	curve kyber.Group,
+	g2 kyber.Point,
+	index kyber.Scalar,
+	longTermShare kyber.Scalar,
+	longTermShare2 kyber.Scalar,
+	longTermPubPoly dkg.PointTuple,
+	randomShare kyber.Scalar,
+	randomShare2 kyber.Scalar,
+	randomPubPoly dkg.PointTuple,
+	participants []Participant,
+	t int,
+	msg []byte,
+) (*DSS, error) {
+	if len(longTermPubPoly) == 0 || len(randomPubPoly) == 0 {
+		return nil, fmt.Errorf("tdss: verification points must not be empty")
+	}
+	if t <= 0 || t > len(participants) {
+		return nil, fmt.Errorf("tdss: threshold %d unreachable by %d participants", t, len(participants))
+	}
+
+	r := dkg.EvalExp(curve, randomPubPoly, curve.Scalar().Zero())
+	c := hashChallenge(curve, r, msg)
+
+	return &DSS{
+		curve: curve,
+		g2:    g2,
+
+		index:           index,
+		longTermShare:   longTermShare,
+		longTermShare2:  longTermShare2,
+		longTermPubPoly: longTermPubPoly,
+		randomShare:     randomShare,
+		randomShare2:    randomShare2,
+		randomPubPoly:   randomPubPoly,
+
+		participants: participants,
+		t:            t,
+		msg:          msg,
+
+		r:         r,
+		challenge: c,
+		partials:  make(map[string]PartialSig),
+	}, nil
+}
+
+// PartialSig computes and records this participant's own contribution
+// s_i = r_i + c*x_i (mod q), together with its PedersenVSS companion
+// u_i = v_i + c*w_i (mod q), which is the curve's zero scalar when the DKG
+// was run with FeldmanVSS.
+func (d *DSS) PartialSig() (kyber.Scalar, kyber.Scalar, kyber.Scalar) {
+	s := d.curve.Scalar().Mul(d.challenge, d.longTermShare)
+	s.Add(s, d.randomShare)
+
+	u := d.curve.Scalar().Mul(d.challenge, d.longTermShare2)
+	u.Add(u, d.randomShare2)
+
+	d.partials[d.index.String()] = PartialSig{Index: d.index, S: s, S2: u}
+	return d.index, s, u
+}
+
+// ProcessPartialSig verifies a partial signature against the verification
+// vectors for the long-term and random secrets and, if valid, records it for
+// later combination. Under PedersenVSS those vectors are blinded by g2, so
+// the check recomputes g^{s_i}*g2^{u_i} == R_i*Y_i^c; under FeldmanVSS, S2 is
+// always zero and the g2 term drops out, leaving the plain g^{s_i} ==
+// R_i*Y_i^c check. A share from an index outside this session's quorum, or
+// one that fails the above check, is reported as an InvalidPartialSigError
+// naming the offending participant.
+func (d *DSS) ProcessPartialSig(ps PartialSig) error {
+	if !d.isParticipant(ps.Index) {
+		return InvalidPartialSigError{Index: ps.Index}
+	}
+
+	ri := dkg.EvalExp(d.curve, d.randomPubPoly, ps.Index)
+	yi := dkg.EvalExp(d.curve, d.longTermPubPoly, ps.Index)
+
+	lhs := d.curve.Point().Mul(ps.S, nil)
+	lhs.Add(lhs, d.curve.Point().Mul(ps.S2, d.g2))
+
+	rhs := d.curve.Point().Mul(d.challenge, yi)
+	rhs.Add(rhs, ri)
+
+	if !lhs.Equal(rhs) {
+		return InvalidPartialSigError{Index: ps.Index}
+	}
+
+	d.partials[ps.Index.String()] = ps
+	return nil
+}
+
+// isParticipant reports whether idx belongs to this session's configured
+// quorum.
+func (d *DSS) isParticipant(idx kyber.Scalar) bool {
+	for _, p := range d.participants {
+		if p.Index.Equal(idx) {
+			return true
+		}
+	}
+	return false
+}
+
+// EnoughPartialSigs reports whether at least t valid partial signatures have
+// been collected so Signature can Lagrange-interpolate the aggregate s.
+func (d *DSS) EnoughPartialSigs() bool {
+	return len(d.partials) >= d.t
+}
+
+// Signature Lagrange-interpolates the collected partial signatures at zero
+// to recover s and its PedersenVSS companion u, and returns the triple
+// (R, s, u), checked against the long-term verification points by
+// VerifySignature. Under FeldmanVSS u is always the curve's zero scalar and
+// (R, s) is a standard Schnorr signature, verifiable with the
+// single-generator check g^s == R*Y^c against Y = longTermPubPoly(0); under
+// PedersenVSS R and Y are themselves blinded by g2, so the triple must be
+// checked with VerifySignature's two-generator equation instead, which
+// collapses to the same check once u is zero.
+func (d *DSS) Signature() (kyber.Point, kyber.Scalar, kyber.Scalar, error) {
+	if !d.EnoughPartialSigs() {
+		return nil, nil, nil, fmt.Errorf("tdss: only %d of %d required partial signatures collected", len(d.partials), d.t)
+	}
+
+	indices := make([]kyber.Scalar, 0, len(d.partials))
+	shares := make([]kyber.Scalar, 0, len(d.partials))
+	shares2 := make([]kyber.Scalar, 0, len(d.partials))
+	for _, ps := range d.partials {
+		indices = append(indices, ps.Index)
+		shares = append(shares, ps.S)
+		shares2 = append(shares2, ps.S2)
+	}
+
+	s := lagrangeInterpolateAtZero(d.curve, indices, shares)
+	u := lagrangeInterpolateAtZero(d.curve, indices, shares2)
+	return d.r, s, u, nil
+}
+
+// VerifySignature checks a (R, s, u) triple produced by Signature against
+// longTermPubPoly, the long-term secret's published verification points,
+// confirming g^s*g2^u == R*Y^c for c = H(R || msg) and Y = eval(
+// longTermPubPoly, 0). Under FeldmanVSS u is always the curve's zero scalar,
+// Y is the plain group public key, and this collapses to the standard
+// single-generator Schnorr check.
+func VerifySignature(curve kyber.Group, g2 kyber.Point, longTermPubPoly dkg.PointTuple, msg []byte, r kyber.Point, s, u kyber.Scalar) error {
+	c := hashChallenge(curve, r, msg)
+	y := dkg.EvalExp(curve, longTermPubPoly, curve.Scalar().Zero())
+
+	lhs := curve.Point().Mul(s, nil)
+	lhs.Add(lhs, curve.Point().Mul(u, g2))
+
+	rhs := curve.Point().Add(r, curve.Point().Mul(c, y))
+
+	if !lhs.Equal(rhs) {
+		return fmt.Errorf("tdss: aggregate signature does not verify against the long-term verification points")
+	}
+	return nil
+}
+
+// hashChallenge computes c = H(R || msg) as a scalar.
+func hashChallenge(curve kyber.Group, r kyber.Point, msg []byte) kyber.Scalar {
+	h := sha256.New()
+	rBytes, _ := r.MarshalBinary()
+	h.Write(rBytes)
+	h.Write(msg)
+	return curve.Scalar().SetBytes(h.Sum(nil))
+}
+
+// lagrangeInterpolateAtZero recovers f(0) given t pairs (indices[i], f(indices[i])).
+func lagrangeInterpolateAtZero(curve kyber.Group, indices, shares []kyber.Scalar) kyber.Scalar {
+	result := curve.Scalar().Zero()
+	for i, xi := range indices {
+		num := curve.Scalar().One()
+		den := curve.Scalar().One()
+		for j, xj := range indices {
+			if i == j {
+				continue
+			}
+			num.Mul(num, xj)
+			diff := curve.Scalar().Sub(xj, xi)
+			den.Mul(den, diff)
+		}
+		coeff := curve.Scalar().Div(num, den)
+		term := curve.Scalar().Mul(coeff, shares[i])
+		result.Add(result, term)
+	}
+	return result
+}
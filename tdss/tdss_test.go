@@ -0,0 +1,163 @@
+package tdss
+
+import (
+	"testing"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/pairing/bn256"
+	"github.com/vaporyorg/dkg"
+	"github.com/vaporyorg/dkg/internal/dkgtest"
+)
+
+// runDSSRoundTrip drives a full distributed Schnorr signature over msg for a
+// quorum of len(indices) participants sharing a long-term secret and a fresh
+// per-signature random secret, both dealt via vss, then verifies the
+// aggregate signature against the long-term verification points.
+func runDSSRoundTrip(t *testing.T, vss dkg.VSS) {
+	t.Helper()
+
+	curve := bn256.NewSuite().G1()
+	g2 := curve.Point().Base()
+	g2.Mul(curve.Scalar().SetInt64(42), g2)
+
+	threshold := 3
+	indices := make([]kyber.Scalar, threshold)
+	for i := range indices {
+		indices[i] = curve.Scalar().SetInt64(int64(i + 1))
+	}
+
+	longTermPubPoly, longTermShares1, longTermShares2, _ := dkgtest.DealShares(t, curve, g2, vss, indices)
+	randomPubPoly, randomShares1, randomShares2, _ := dkgtest.DealShares(t, curve, g2, vss, indices)
+
+	msg := []byte("distributed schnorr signing")
+
+	participants := make([]Participant, len(indices))
+	for i, idx := range indices {
+		participants[i] = Participant{Index: idx}
+	}
+
+	sessions := make([]*DSS, len(indices))
+	for i, idx := range indices {
+		d, err := NewDSS(
+			curve, g2, idx,
+			longTermShares1[i], longTermShares2[i], longTermPubPoly,
+			randomShares1[i], randomShares2[i], randomPubPoly,
+			participants, threshold, msg,
+		)
+		if err != nil {
+			t.Fatalf("NewDSS failed for participant %d: %v", i, err)
+		}
+		sessions[i] = d
+	}
+
+	partials := make([]PartialSig, len(indices))
+	for i, d := range sessions {
+		_, s, s2 := d.PartialSig()
+		partials[i] = PartialSig{Index: indices[i], S: s, S2: s2}
+	}
+
+	for _, d := range sessions {
+		for _, ps := range partials {
+			if err := d.ProcessPartialSig(ps); err != nil {
+				t.Fatalf("ProcessPartialSig rejected a genuine partial signature: %v", err)
+			}
+		}
+	}
+
+	r, s, u, err := sessions[0].Signature()
+	if err != nil {
+		t.Fatalf("Signature failed: %v", err)
+	}
+
+	if err := VerifySignature(curve, g2, longTermPubPoly, msg, r, s, u); err != nil {
+		t.Errorf("aggregate signature does not verify against the long-term verification points: %v", err)
+	}
+}
+
+func TestDSSRoundTripFeldman(t *testing.T) {
+	curve := bn256.NewSuite().G1()
+	runDSSRoundTrip(t, dkg.NewFeldmanVSS(curve))
+}
+
+func TestDSSRoundTripPedersen(t *testing.T) {
+	curve := bn256.NewSuite().G1()
+	g2 := curve.Point().Base()
+	g2.Mul(curve.Scalar().SetInt64(42), g2)
+	runDSSRoundTrip(t, dkg.NewPedersenVSS(curve, g2))
+}
+
+func TestProcessPartialSigRejectsMalformedShare(t *testing.T) {
+	curve := bn256.NewSuite().G1()
+	g2 := curve.Point().Base()
+	g2.Mul(curve.Scalar().SetInt64(42), g2)
+
+	threshold := 3
+	indices := make([]kyber.Scalar, threshold)
+	for i := range indices {
+		indices[i] = curve.Scalar().SetInt64(int64(i + 1))
+	}
+
+	vss := dkg.NewPedersenVSS(curve, g2)
+	longTermPubPoly, longTermShares1, longTermShares2, _ := dkgtest.DealShares(t, curve, g2, vss, indices)
+	randomPubPoly, randomShares1, randomShares2, _ := dkgtest.DealShares(t, curve, g2, vss, indices)
+
+	participants := make([]Participant, len(indices))
+	for i, idx := range indices {
+		participants[i] = Participant{Index: idx}
+	}
+
+	d, err := NewDSS(
+		curve, g2, indices[0],
+		longTermShares1[0], longTermShares2[0], longTermPubPoly,
+		randomShares1[0], randomShares2[0], randomPubPoly,
+		participants, threshold, []byte("msg"),
+	)
+	if err != nil {
+		t.Fatalf("NewDSS failed: %v", err)
+	}
+
+	bad := PartialSig{Index: indices[1], S: curve.Scalar().SetInt64(1), S2: curve.Scalar().SetInt64(1)}
+	if err := d.ProcessPartialSig(bad); err == nil {
+		t.Errorf("expected a malformed partial signature to be rejected")
+	}
+}
+
+func TestProcessPartialSigRejectsUnknownParticipant(t *testing.T) {
+	curve := bn256.NewSuite().G1()
+	g2 := curve.Point().Base()
+	g2.Mul(curve.Scalar().SetInt64(42), g2)
+
+	threshold := 3
+	indices := make([]kyber.Scalar, threshold)
+	for i := range indices {
+		indices[i] = curve.Scalar().SetInt64(int64(i + 1))
+	}
+
+	vss := dkg.NewPedersenVSS(curve, g2)
+	longTermPubPoly, longTermShares1, longTermShares2, _ := dkgtest.DealShares(t, curve, g2, vss, indices)
+	randomPubPoly, randomShares1, randomShares2, _ := dkgtest.DealShares(t, curve, g2, vss, indices)
+
+	participants := make([]Participant, len(indices))
+	for i, idx := range indices {
+		participants[i] = Participant{Index: idx}
+	}
+
+	d, err := NewDSS(
+		curve, g2, indices[0],
+		longTermShares1[0], longTermShares2[0], longTermPubPoly,
+		randomShares1[0], randomShares2[0], randomPubPoly,
+		participants, threshold, []byte("msg"),
+	)
+	if err != nil {
+		t.Fatalf("NewDSS failed: %v", err)
+	}
+
+	outsider := PartialSig{
+		Index: curve.Scalar().SetInt64(999),
+		S:     curve.Scalar().SetInt64(1),
+		S2:    curve.Scalar().SetInt64(1),
+	}
+	if err := d.ProcessPartialSig(outsider); err == nil {
+		t.Errorf("expected a partial signature from outside the quorum to be rejected")
+	}
+}
@@ -0,0 +1,210 @@
+// Package tenc implements threshold ElGamal decryption, and re-encryption to
+// a new recipient, on top of the shared secret produced by a DKG node: t of
+// n participants each contribute a share D_i = x_i·K of the masking point,
+// proven consistent with their public share via a Chaum-Pedersen proof, and
+// a combiner Lagrange-interpolates those shares in the exponent to recover
+// the mask without ever reconstructing the group secret x as a scalar.
+package tenc
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/util/random"
+	"github.com/vaporyorg/dkg"
+)
+
+// Ciphertext is an ElGamal ciphertext keyed by a DKG group public key:
+// K = g^k is the ephemeral public key and C is the message point masked by
+// the shared secret Y^k.
+type Ciphertext struct {
+	K, C kyber.Point
+}
+
+// Encrypt ElGamal-encrypts msg under groupPub, the DKG's group public key. As
+// with dkg.ElGamalEncrypt, which it delegates to, msg must fit within one
+// Point's EmbedLen; any bytes beyond that are returned as remainder,
+// unencrypted.
+func Encrypt(group kyber.Group, groupPub kyber.Point, msg []byte) (ct Ciphertext, remainder []byte) {
+	K, C, remainder := dkg.ElGamalEncrypt(group, groupPub, msg)
+	return Ciphertext{K: K, C: C}, remainder
+}
+
+// ZKProof is a non-interactive generalized Chaum-Pedersen proof that the
+// participant's decryption share D and its published (Feldman or Pedersen)
+// verification point Y were produced from the same secret share x, i.e. that
+// D = K^x and Y = g^x*g2^w for some w, without revealing x or w. Under
+// FeldmanVSS w is always zero, so Y = g^x, but the proof keeps its g2 term.
+type ZKProof struct {
+	Challenge kyber.Scalar
+	ResponseX kyber.Scalar
+	ResponseW kyber.Scalar
+}
+
+// InvalidDecryptionShareError reports that a decryption share's
+// Chaum-Pedersen proof failed to verify against the group's published
+// verification points, naming the offending index.
+type InvalidDecryptionShareError struct {
+	Index kyber.Scalar
+}
+
+func (e InvalidDecryptionShareError) Error() string {
+	return fmt.Sprintf("tenc: invalid decryption share from participant %v", e.Index)
+}
+
+// Node drives one participant's side of a threshold ElGamal decryption,
+// combining its DKG secret share with whichever ciphertexts it is asked to
+// contribute a decryption share towards.
+type Node struct {
+	curve kyber.Group
+	g2    kyber.Point
+
+	index        kyber.Scalar
+	secretShare  kyber.Scalar
+	secretShare2 kyber.Scalar
+}
+
+// NewNode constructs a threshold decryption participant around index's final
+// DKG secret share (the sum of the shares it received from every dealer in
+// QUAL). g2 must be the same second Pedersen generator the DKG was run with.
+// secretShare2 is the corresponding share of the DKG's second (hiding)
+// polynomial under PedersenVSS, i.e. the second share a dealer hands out
+// from EvaluatePolynomials; pass the curve's zero scalar when the DKG was run
+// with FeldmanVSS, which has no second polynomial.
+func NewNode(curve kyber.Group, g2 kyber.Point, index kyber.Scalar, secretShare, secretShare2 kyber.Scalar) *Node {
+	return &Node{curve: curve, g2: g2, index: index, secretShare: secretShare, secretShare2: secretShare2}
+}
+
+// DecryptionShare computes this participant's contribution D = x_i·K to the
+// threshold decryption of ct, together with a generalized Chaum-Pedersen
+// proof that D and this participant's public share Y_i = g^{x_i}*g2^{w_i}
+// were produced from the same x_i, so VerifyDecryptionShare can catch a
+// lying participant without ever learning x_i or w_i.
+func (n *Node) DecryptionShare(ct Ciphertext) (idx kyber.Scalar, D kyber.Point, proof ZKProof) {
+	D = n.curve.Point().Mul(n.secretShare, ct.K)
+	Y := n.curve.Point().Mul(n.secretShare, nil)
+	Y.Add(Y, n.curve.Point().Mul(n.secretShare2, n.g2))
+
+	u := n.curve.Scalar().Pick(random.New())
+	v := n.curve.Scalar().Pick(random.New())
+	a1 := n.curve.Point().Mul(u, nil)
+	a1.Add(a1, n.curve.Point().Mul(v, n.g2))
+	a2 := n.curve.Point().Mul(u, ct.K)
+
+	c := hashChallenge(n.curve, ct.K, Y, D, a1, a2)
+
+	rx := n.curve.Scalar().Mul(c, n.secretShare)
+	rx.Sub(u, rx)
+	rw := n.curve.Scalar().Mul(c, n.secretShare2)
+	rw.Sub(v, rw)
+
+	return n.index, D, ZKProof{Challenge: c, ResponseX: rx, ResponseW: rw}
+}
+
+// VerifyDecryptionShare checks a decryption share (idx, D, proof) against
+// verificationPoints, the group's aggregate (Feldman or Pedersen) commitment
+// polynomial, confirming that D = K^{x_idx} for the same x_idx committed to
+// by Y_idx = eval(verificationPoints, idx), without learning x_idx. g2 must
+// be the same second Pedersen generator the share's Node was built with,
+// under FeldmanVSS verification points as well: g2^{proof.ResponseW} always
+// enters the recomputed commitment a1.
+func VerifyDecryptionShare(curve kyber.Group, g2 kyber.Point, verificationPoints dkg.PointTuple, idx kyber.Scalar, ct Ciphertext, D kyber.Point, proof ZKProof) error {
+	Y := dkg.EvalExp(curve, verificationPoints, idx)
+
+	a1 := curve.Point().Mul(proof.ResponseX, nil)
+	a1.Add(a1, curve.Point().Mul(proof.ResponseW, g2))
+	a1.Add(a1, curve.Point().Mul(proof.Challenge, Y))
+
+	a2 := curve.Point().Mul(proof.ResponseX, ct.K)
+	a2.Add(a2, curve.Point().Mul(proof.Challenge, D))
+
+	c := hashChallenge(curve, ct.K, Y, D, a1, a2)
+	if !c.Equal(proof.Challenge) {
+		return InvalidDecryptionShareError{Index: idx}
+	}
+	return nil
+}
+
+// Share is one participant's decryption share of a Ciphertext, as produced
+// by Node.DecryptionShare and checked by VerifyDecryptionShare.
+type Share struct {
+	Index kyber.Scalar
+	D     kyber.Point
+}
+
+// CombineShares Lagrange-interpolates threshold of the given decryption
+// shares in the exponent to recover S = x·K, the same masking point a
+// single-party ElGamal encryption under the group public key would have
+// produced, without ever reconstructing x itself.
+func CombineShares(curve kyber.Group, shares []Share, threshold int) (kyber.Point, error) {
+	if len(shares) < threshold {
+		return nil, fmt.Errorf("tenc: only %d of %d required decryption shares collected", len(shares), threshold)
+	}
+	shares = shares[:threshold]
+
+	S := curve.Point().Null()
+	for i, share := range shares {
+		lambda := lagrangeCoefficientAtZero(curve, shares, i)
+		S.Add(S, curve.Point().Mul(lambda, share.D))
+	}
+	return S, nil
+}
+
+// Decrypt recovers the plaintext embedded in ct given the masking point S
+// produced by CombineShares, mirroring dkg.ElGamalDecrypt.
+func Decrypt(curve kyber.Group, ct Ciphertext, S kyber.Point) ([]byte, error) {
+	M := curve.Point().Sub(ct.C, S)
+	return M.Data()
+}
+
+// Reshare re-encrypts the message carried by ct under newPub without any
+// participant, or the combiner, ever reconstructing the group secret x as a
+// scalar: threshold of the supplied decryption shares are combined in the
+// exponent to recover the masking point S = x·K, which is peeled off ct and
+// replaced with a freshly sampled ephemeral key's mask for the new
+// recipient in the same step.
+func Reshare(curve kyber.Group, ct Ciphertext, shares []Share, threshold int, newPub kyber.Point) (Ciphertext, error) {
+	S, err := CombineShares(curve, shares, threshold)
+	if err != nil {
+		return Ciphertext{}, err
+	}
+	M := curve.Point().Sub(ct.C, S)
+
+	k2 := curve.Scalar().Pick(random.New()) // fresh ephemeral private key
+	K2 := curve.Point().Mul(k2, nil)        // fresh ephemeral DH public key
+	S2 := curve.Point().Mul(k2, newPub)     // fresh ephemeral DH shared secret
+	C2 := S2.Add(S2, M)                     // message re-blinded under the new recipient
+
+	return Ciphertext{K: K2, C: C2}, nil
+}
+
+// hashChallenge computes c = H(K || Y || D || a1 || a2) as a scalar, the
+// Fiat-Shamir challenge for the Chaum-Pedersen proof that Y and D share a
+// discrete log relative to g and K respectively.
+func hashChallenge(curve kyber.Group, points ...kyber.Point) kyber.Scalar {
+	h := sha256.New()
+	for _, p := range points {
+		b, _ := p.MarshalBinary()
+		h.Write(b)
+	}
+	return curve.Scalar().SetBytes(h.Sum(nil))
+}
+
+// lagrangeCoefficientAtZero computes the Lagrange basis coefficient
+// lambda_i(0) for shares[i], i.e. the weight that scales its contribution
+// when interpolating the shared polynomial at x=0.
+func lagrangeCoefficientAtZero(curve kyber.Group, shares []Share, i int) kyber.Scalar {
+	xi := shares[i].Index
+	num := curve.Scalar().One()
+	den := curve.Scalar().One()
+	for j, sj := range shares {
+		if i == j {
+			continue
+		}
+		num.Mul(num, sj.Index)
+		diff := curve.Scalar().Sub(sj.Index, xi)
+		den.Mul(den, diff)
+	}
+	return curve.Scalar().Div(num, den)
+}
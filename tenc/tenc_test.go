@@ -0,0 +1,139 @@
+package tenc
+
+import (
+	"testing"
+
+	"github.com/dedis/kyber"
+	"github.com/dedis/kyber/pairing/bn256"
+	"github.com/dedis/kyber/util/random"
+	"github.com/vaporyorg/dkg"
+	"github.com/vaporyorg/dkg/internal/dkgtest"
+)
+
+// runEncryptDecryptThresholdRoundTrip drives a full threshold ElGamal
+// decryption for a quorum of len(indices) participants sharing a secret
+// dealt via vss, then checks the recovered plaintext.
+func runEncryptDecryptThresholdRoundTrip(t *testing.T, vss dkg.VSS) {
+	t.Helper()
+
+	curve := bn256.NewSuite().G1()
+	g2 := curve.Point().Base()
+	g2.Mul(curve.Scalar().SetInt64(42), g2)
+
+	threshold := 3
+	indices := make([]kyber.Scalar, threshold)
+	for i := range indices {
+		indices[i] = curve.Scalar().SetInt64(int64(i + 1))
+	}
+
+	verificationPoints, shares1, shares2, groupPub := dkgtest.DealShares(t, curve, g2, vss, indices)
+
+	msg := []byte("threshold elgamal")
+	ct, remainder := Encrypt(curve, groupPub, msg)
+	if len(remainder) != 0 {
+		t.Fatalf("unexpected remainder: %v", remainder)
+	}
+
+	var shares []Share
+	for i := range indices {
+		node := NewNode(curve, g2, indices[i], shares1[i], shares2[i])
+		idx, D, proof := node.DecryptionShare(ct)
+
+		if err := VerifyDecryptionShare(curve, g2, verificationPoints, idx, ct, D, proof); err != nil {
+			t.Fatalf("VerifyDecryptionShare failed for index %v: %v", idx, err)
+		}
+		shares = append(shares, Share{Index: idx, D: D})
+	}
+
+	S, err := CombineShares(curve, shares, threshold)
+	if err != nil {
+		t.Fatalf("CombineShares failed: %v", err)
+	}
+
+	got, err := Decrypt(curve, ct, S)
+	if err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	if string(got) != string(msg) {
+		t.Errorf("got %q, want %q", got, msg)
+	}
+}
+
+func TestEncryptDecryptThresholdRoundTripFeldman(t *testing.T) {
+	curve := bn256.NewSuite().G1()
+	runEncryptDecryptThresholdRoundTrip(t, dkg.NewFeldmanVSS(curve))
+}
+
+func TestEncryptDecryptThresholdRoundTripPedersen(t *testing.T) {
+	curve := bn256.NewSuite().G1()
+	g2 := curve.Point().Base()
+	g2.Mul(curve.Scalar().SetInt64(42), g2)
+	runEncryptDecryptThresholdRoundTrip(t, dkg.NewPedersenVSS(curve, g2))
+}
+
+func TestVerifyDecryptionShareRejectsTamperedProof(t *testing.T) {
+	curve := bn256.NewSuite().G1()
+	g2 := curve.Point().Base()
+	g2.Mul(curve.Scalar().SetInt64(42), g2)
+
+	threshold := 2
+	indices := make([]kyber.Scalar, threshold)
+	for i := range indices {
+		indices[i] = curve.Scalar().SetInt64(int64(i + 1))
+	}
+
+	vss := dkg.NewPedersenVSS(curve, g2)
+	verificationPoints, shares1, shares2, groupPub := dkgtest.DealShares(t, curve, g2, vss, indices)
+
+	ct, _ := Encrypt(curve, groupPub, []byte("x"))
+	node := NewNode(curve, g2, indices[0], shares1[0], shares2[0])
+	gotIdx, D, proof := node.DecryptionShare(ct)
+
+	proof.ResponseX = curve.Scalar().Pick(random.New())
+	if err := VerifyDecryptionShare(curve, g2, verificationPoints, gotIdx, ct, D, proof); err == nil {
+		t.Errorf("expected tampered proof to fail verification")
+	}
+}
+
+func TestReshareRoundTrip(t *testing.T) {
+	curve := bn256.NewSuite().G1()
+	g2 := curve.Point().Base()
+	g2.Mul(curve.Scalar().SetInt64(42), g2)
+
+	threshold := 2
+	indices := make([]kyber.Scalar, threshold)
+	for i := range indices {
+		indices[i] = curve.Scalar().SetInt64(int64(i + 1))
+	}
+
+	vss := dkg.NewFeldmanVSS(curve)
+	_, shares1, shares2, groupPub := dkgtest.DealShares(t, curve, g2, vss, indices)
+
+	msg := []byte("reshare me")
+	ct, _ := Encrypt(curve, groupPub, msg)
+
+	var shares []Share
+	for i := range indices {
+		node := NewNode(curve, g2, indices[i], shares1[i], shares2[i])
+		idx, D, _ := node.DecryptionShare(ct)
+		shares = append(shares, Share{Index: idx, D: D})
+	}
+
+	newPriv := curve.Scalar().Pick(random.New())
+	newPub := curve.Point().Mul(newPriv, nil)
+
+	ct2, err := Reshare(curve, ct, shares, threshold, newPub)
+	if err != nil {
+		t.Fatalf("Reshare failed: %v", err)
+	}
+
+	S := curve.Point().Mul(newPriv, ct2.K)
+	M := curve.Point().Sub(ct2.C, S)
+	got, err := M.Data()
+	if err != nil {
+		t.Fatalf("could not recover data: %v", err)
+	}
+	if string(got) != string(msg) {
+		t.Errorf("got %q, want %q", got, msg)
+	}
+}
@@ -0,0 +1,48 @@
+package dkg
+
+import "github.com/dedis/kyber"
+
+// VSS abstracts the verifiable secret sharing commitment scheme a node uses
+// to let other participants check the shares it deals, so callers can choose
+// between Pedersen's information-theoretically hiding scheme and Feldman's
+// shorter, binding-only one.
+type VSS interface {
+	// Valid reports whether polys is an acceptable set of secret
+	// polynomials for this scheme, e.g. Pedersen requires exactly two
+	// non-empty polynomials of equal degree, Feldman exactly one.
+	Valid(polys []ScalarPolynomial) error
+
+	// Commit returns the verification points for polys, one per
+	// coefficient.
+	Commit(polys []ScalarPolynomial) PointTuple
+
+	// Verify checks that shares, evaluated at id, are consistent with the
+	// published verification points C.
+	Verify(id kyber.Scalar, shares []kyber.Scalar, C PointTuple) bool
+
+	// Reveal extracts the public key part, i.e. the commitment to the
+	// constant term, from a node's own secret polynomials.
+	Reveal(polys []ScalarPolynomial) kyber.Point
+
+	// NumPolynomials reports how many secret polynomials this scheme deals
+	// per participant, e.g. two for Pedersen's hiding/binding pair, one for
+	// Feldman. Callers use it to decide whether a second share/polynomial
+	// needs to be generated, sent, or verified, instead of type-asserting a
+	// concrete implementation.
+	NumPolynomials() int
+}
+
+// EvalExp evaluates a verification polynomial in the exponent at x, i.e.
+// computes Sum_j points[j]^{x^j}. It is exported so packages built on top of
+// a DKG node, such as tdss and tenc, can check their own Chaum-Pedersen-style
+// proofs against the same Feldman/Pedersen verification points without
+// carrying a second copy of the evaluation.
+func EvalExp(curve kyber.Group, points PointTuple, x kyber.Scalar) kyber.Point {
+	result := curve.Point().Null()
+	xPow := curve.Scalar().One()
+	for _, p := range points {
+		result.Add(result, curve.Point().Mul(xPow, p))
+		xPow.Mul(xPow, x)
+	}
+	return result
+}